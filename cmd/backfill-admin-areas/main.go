@@ -0,0 +1,52 @@
+// backfill-admin-areas tags the reports that have no state with the
+// administrative areas they were made in. It is run once after reports
+// started being geocoded, and again whenever the boundaries are extended.
+// With -retag every report is tagged again, for when the boundaries are
+// replaced or corrected.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/olad5/caution-companion/config"
+	"github.com/olad5/caution-companion/config/data"
+	"github.com/olad5/caution-companion/internal/infra/postgres"
+	"github.com/olad5/caution-companion/internal/services/geocode"
+	"github.com/olad5/caution-companion/pkg/utils/logger"
+)
+
+func main() {
+	retag := flag.Bool("retag", false, "tag every report again, not only the ones without a state")
+	flag.Parse()
+
+	configurations := config.GetConfig(".env")
+	ctx := context.Background()
+
+	l := logger.Get(configurations)
+
+	postgresConnection := data.StartPostgres(configurations.DatabaseUrl, l)
+	if err := postgres.Migrate(ctx, postgresConnection); err != nil {
+		log.Fatal("Error Migrating postgres", err)
+	}
+
+	defer postgresConnection.Close()
+
+	reportsRepo, err := postgres.NewPostgresReportRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Reports Repo", err)
+	}
+
+	geocoder, err := geocode.NewGeocoder(configurations.BoundariesFile)
+	if err != nil {
+		log.Fatal("Error Initializing Geocoder", err)
+	}
+
+	tagged, err := geocode.BackfillAdminAreas(ctx, reportsRepo, geocoder, *retag)
+	if err != nil {
+		log.Fatalf("Error backfilling admin areas after tagging %d reports: %v", tagged, err)
+	}
+	fmt.Printf("Tagged %d reports with their admin areas\n", tagged)
+}
@@ -12,11 +12,18 @@ import (
 
 	"github.com/olad5/caution-companion/config"
 	"github.com/olad5/caution-companion/config/data"
+	"github.com/olad5/caution-companion/internal/domain"
 	loggingMiddleware "github.com/olad5/caution-companion/internal/handlers/logging"
+	"github.com/olad5/caution-companion/internal/infra"
 	"github.com/olad5/caution-companion/internal/infra/cloudinary"
+	"github.com/olad5/caution-companion/internal/infra/lognotifier"
 	"github.com/olad5/caution-companion/internal/infra/postgres"
 	"github.com/olad5/caution-companion/internal/infra/redis"
 	"github.com/olad5/caution-companion/internal/infra/smtpexpress"
+	"github.com/olad5/caution-companion/internal/infra/webpush"
+	"github.com/olad5/caution-companion/internal/services/events"
+	"github.com/olad5/caution-companion/internal/services/jobs"
+	"github.com/olad5/caution-companion/internal/services/notify"
 	"github.com/olad5/caution-companion/pkg/api"
 	"github.com/olad5/caution-companion/pkg/utils/logger"
 )
@@ -44,6 +51,41 @@ func main() {
 		log.Fatal("Error Initializing Reports Repo", err)
 	}
 
+	incidentTypeRepo, err := postgres.NewPostgresIncidentTypeRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Incident Types Repo", err)
+	}
+
+	commentRepo, err := postgres.NewPostgresCommentRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Comments Repo", err)
+	}
+
+	fileRepo, err := postgres.NewPostgresFileRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Files Repo", err)
+	}
+
+	alertRepo, err := postgres.NewPostgresAlertSubscriptionRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Alert Subscriptions Repo", err)
+	}
+
+	deviceRepo, err := postgres.NewPostgresDeviceRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Devices Repo", err)
+	}
+
+	preferenceRepo, err := postgres.NewPostgresNotificationPreferenceRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Notification Preferences Repo", err)
+	}
+
+	moderationRepo, err := postgres.NewPostgresModerationRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Moderation Repo", err)
+	}
+
 	redisCache, err := redis.New(ctx, configurations)
 	if err != nil {
 		log.Fatal("Error Initializing redisCache", err)
@@ -59,18 +101,75 @@ func main() {
 		log.Fatal("Error Initializing smtpexpress mailservice", err)
 	}
 
+	broadcaster, err := events.NewBroadcaster(redisCache, l)
+	if err != nil {
+		log.Fatal("Error Initializing broadcaster", err)
+	}
+	if err := broadcaster.Start(ctx); err != nil {
+		log.Fatal("Error Starting broadcaster", err)
+	}
+
+	mailNotifier, err := notify.NewMailNotifier(mailService)
+	if err != nil {
+		log.Fatal("Error Initializing mail notifier", err)
+	}
+	var pushNotifier infra.Notifier
+	if configurations.VapidPrivateKey != "" {
+		pushNotifier, err = webpush.New(ctx, configurations, deviceRepo)
+		if err != nil {
+			log.Fatal("Error Initializing web push", err)
+		}
+	} else {
+		logNotifier, err := lognotifier.New(domain.NotificationChannelPush, configurations.NotificationLogFile)
+		if err != nil {
+			log.Fatal("Error Initializing log notifier", err)
+		}
+		defer logNotifier.Close()
+		pushNotifier = logNotifier
+	}
+	dispatcher, err := notify.NewDispatcher(deviceRepo, preferenceRepo, mailNotifier, pushNotifier)
+	if err != nil {
+		log.Fatal("Error Initializing notification dispatcher", err)
+	}
+
+	scheduler, err := jobs.NewScheduler(redisCache, l)
+	if err != nil {
+		log.Fatal("Error Initializing job scheduler", err)
+	}
+	reportExpiryJob, err := jobs.NewReportExpiryJob(reportsRepo, broadcaster)
+	if err != nil {
+		log.Fatal("Error Initializing report expiry job", err)
+	}
+	if err := scheduler.Register(reportExpiryJob); err != nil {
+		log.Fatal("Error Registering report expiry job", err)
+	}
+	scheduler.Start(ctx)
+
 	appRouter := api.NewHttpRouter(
 		ctx,
 		userRepo,
 		reportsRepo,
+		incidentTypeRepo,
+		commentRepo,
+		fileRepo,
+		alertRepo,
+		deviceRepo,
+		preferenceRepo,
+		moderationRepo,
 		fileStore,
 		redisCache,
+		broadcaster,
+		dispatcher,
 		mailService,
 		configurations,
 		l)
 
 	port := configurations.Port
 	server := &http.Server{Addr: ":" + port, Handler: loggingMiddleware.RequestLogger(appRouter, configurations)}
+	// streams and WebSocket sessions are not tracked by Shutdown, closing the
+	// broadcaster ends them
+	server.RegisterOnShutdown(broadcaster.Close)
+	server.RegisterOnShutdown(scheduler.Close)
 	go func() {
 		message := "Server is running on port " + port
 		fmt.Println(message)
@@ -89,6 +188,12 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		fmt.Printf("Server forced to shutdown: %v", err)
 	}
+	if err := broadcaster.Wait(ctx); err != nil {
+		fmt.Printf("Live connections forced to close: %v", err)
+	}
+	if err := scheduler.Wait(ctx); err != nil {
+		fmt.Printf("Background jobs forced to stop: %v", err)
+	}
 
 	fmt.Println("Server exiting gracefully")
 }
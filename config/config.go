@@ -21,6 +21,18 @@ type Configurations struct {
 	CloudinaryUrl            string
 	SenderEmail              string
 	SMTPExpressProjectSecret string
+	// VapidPrivateKey enables web push when set, it is the base64url
+	// encoded P-256 private key the application server signs with
+	VapidPrivateKey string
+	// NotificationLogFile receives push notifications as JSON lines when
+	// web push is not enabled, stdout is used when it is empty
+	NotificationLogFile string
+	// ContentWordListFile is a JSON file of the word lists report text is
+	// checked against, only phone numbers and emails are filtered without it
+	ContentWordListFile string
+	// BoundariesFile is the GeoJSON file of the states and LGAs reports are
+	// tagged with, the server does not start without it
+	BoundariesFile string
 }
 
 func GetConfig(filepath string) *Configurations {
@@ -47,6 +59,10 @@ func GetConfig(filepath string) *Configurations {
 		CloudinaryUrl:            os.Getenv("CLOUDINARY_URL"),
 		SMTPExpressProjectSecret: os.Getenv("SMTPEXPRESS_PROJECT_SECRET"),
 		SenderEmail:              os.Getenv("APP_SENDER_EMAIL"),
+		VapidPrivateKey:          os.Getenv("VAPID_PRIVATE_KEY"),
+		NotificationLogFile:      os.Getenv("NOTIFICATION_LOG_FILE"),
+		ContentWordListFile:      os.Getenv("CONTENT_WORD_LIST_FILE"),
+		BoundariesFile:           os.Getenv("BOUNDARIES_FILE"),
 		AuthSessionTTLInMinutes:  authSessionTTLInMinutes,
 		Environment:              environment,
 	}
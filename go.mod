@@ -1,6 +1,6 @@
 module github.com/olad5/caution-companion
 
-go 1.20
+go 1.22
 
 require (
 	github.com/gabriel-vasile/mimetype v1.4.4
@@ -17,6 +17,8 @@ require (
 	go.mongodb.org/mongo-driver v1.16.0
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.25.0
+	golang.org/x/net v0.27.0
+	golang.org/x/text v0.16.0
 )
 
 require (
@@ -35,7 +37,6 @@ require (
 	github.com/sethvargo/go-retry v0.2.4 // indirect
 	github.com/swaggo/files/v2 v2.0.1 // indirect
 	github.com/swaggo/swag v1.16.3 // indirect
-	golang.org/x/net v0.27.0 // indirect
 	golang.org/x/sys v0.22.0 // indirect
 	golang.org/x/tools v0.23.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -60,5 +61,4 @@ require (
 	github.com/youmark/pkcs8 v0.0.0-20240424034433-3c2c7870ae76 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/sync v0.7.0 // indirect
-	golang.org/x/text v0.16.0 // indirect
 )
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+const (
+	AlertShapeCircle  = "circle"
+	AlertShapePolygon = "polygon"
+)
+
+// AlertSubscription is an area a user wants to be alerted about when new
+// reports are made inside it.
+type AlertSubscription struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Name   string
+	Shape  string
+	// Latitude, Longitude and RadiusInMeters are only set for circles
+	Latitude       float64
+	Longitude      float64
+	RadiusInMeters float64
+	// Polygon is only set for polygons, the last point connects back to the
+	// first one
+	Polygon []geo.Point
+	// IncidentTypes is empty when every incident type should alert
+	IncidentTypes []string
+	// QuietHoursStart and QuietHoursEnd are minutes after midnight in
+	// TimeZone, the window wraps past midnight when the end is before the
+	// start. Quiet hours are off when both are equal.
+	QuietHoursStart int
+	QuietHoursEnd   int
+	TimeZone        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (a AlertSubscription) BoundingBox() geo.BoundingBox {
+	if a.Shape == AlertShapePolygon {
+		return geo.BoundingBoxOf(a.Polygon)
+	}
+	return geo.BoundingBoxAround(a.Latitude, a.Longitude, a.RadiusInMeters)
+}
+
+func (a AlertSubscription) Contains(lat, lng float64) bool {
+	if a.Shape == AlertShapePolygon {
+		return geo.PolygonContains(a.Polygon, lat, lng)
+	}
+	return geo.Distance(a.Latitude, a.Longitude, lat, lng) <= a.RadiusInMeters
+}
+
+func (a AlertSubscription) MatchesIncidentType(incidentType string) bool {
+	return len(a.IncidentTypes) == 0 || slices.Contains(a.IncidentTypes, incidentType)
+}
+
+func (a AlertSubscription) HasQuietHours() bool {
+	return a.QuietHoursStart != a.QuietHoursEnd
+}
+
+// IsQuietAt reports whether t falls inside the quiet hours, an unknown
+// time zone is treated as UTC.
+func (a AlertSubscription) IsQuietAt(t time.Time) bool {
+	if !a.HasQuietHours() {
+		return false
+	}
+	location, err := time.LoadLocation(a.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if a.QuietHoursStart < a.QuietHoursEnd {
+		return minute >= a.QuietHoursStart && minute < a.QuietHoursEnd
+	}
+	return minute >= a.QuietHoursStart || minute < a.QuietHoursEnd
+}
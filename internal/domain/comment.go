@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Comment struct {
+	ID       uuid.UUID
+	ReportID uuid.UUID
+	AuthorID uuid.UUID
+	// ParentID is uuid.Nil for top level comments
+	ParentID  uuid.UUID
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CommentThread struct {
+	Comment
+	Replies []Comment
+}
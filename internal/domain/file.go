@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type File struct {
+	ID        uuid.UUID
+	OwnerID   uuid.UUID
+	Url       string
+	MimeType  string
+	CreatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// DefaultTimeToLive is used for incident types created without one.
+const DefaultTimeToLive = 7 * 24 * time.Hour
+
+type IncidentType struct {
+	Slug            string
+	DisplayName     string
+	DefaultSeverity int
+	Icon            string
+	IsActive        bool
+	// AllowsAnonymous lets reports of this type be made without the
+	// reporter being shown
+	AllowsAnonymous bool
+	// TimeToLive is how long reports of this type stay live before they
+	// expire, zero means they never do
+	TimeToLive time.Duration
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FlagReasonAbusive = "abusive"
+	FlagReasonFake    = "fake"
+	FlagReasonDoxxing = "doxxing"
+	FlagReasonSpam    = "spam"
+	FlagReasonOther   = "other"
+)
+
+// ReportFlag is a user asking moderators to look at a report, it stays
+// pending until a moderator acts on the report.
+type ReportFlag struct {
+	ID       uuid.UUID
+	ReportID uuid.UUID
+	UserID   uuid.UUID
+	Reason   string
+	Note     string
+	// ResolvedAt is zero while the flag is pending
+	ResolvedAt time.Time
+	CreatedAt  time.Time
+}
+
+// FlaggedReport is an entry of the moderation queue.
+type FlaggedReport struct {
+	Report
+	PendingFlags  int
+	LastFlaggedAt time.Time
+	// Reasons counts the pending flags by reason
+	Reasons map[string]int
+}
+
+const (
+	ModerationActionHide    = "hide"
+	ModerationActionRestore = "restore"
+	ModerationActionDelete  = "delete"
+	ModerationActionWarn    = "warn"
+	ModerationActionSuspend = "suspend"
+)
+
+// ModerationAction records what a moderator did. Actions on reports also
+// carry the owner of the report in UserID when it is known.
+type ModerationAction struct {
+	ID          uuid.UUID
+	ModeratorID uuid.UUID
+	Action      string
+	// ReportID is uuid.Nil for actions taken on a user directly
+	ReportID uuid.UUID
+	UserID   uuid.UUID
+	Reason   string
+	// SuspendedUntil is only set for suspensions
+	SuspendedUntil time.Time
+	CreatedAt      time.Time
+}
+
+const (
+	ContentActionMask   = "mask"
+	ContentActionFlag   = "flag"
+	ContentActionReject = "reject"
+)
+
+// ContentFinding is something the content filter found in the text of a
+// report. Masked findings keep the text they replaced so moderators can see
+// what was removed.
+type ContentFinding struct {
+	ID       uuid.UUID
+	ReportID uuid.UUID
+	// Rule names the filter rule, Language is empty for rules that do not
+	// depend on the language of the text
+	Rule      string
+	Language  string
+	Action    string
+	Match     string
+	CreatedAt time.Time
+}
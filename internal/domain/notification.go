@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelPush  = "push"
+)
+
+const DevicePlatformWeb = "web"
+
+// Device is somewhere push notifications can be delivered to a user.
+type Device struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Platform string
+	// Token identifies the device with its push service, for web push it is
+	// the subscription endpoint
+	Token string
+	// P256dh and Auth are the keys of a web push subscription
+	P256dh    string
+	Auth      string
+	UserAgent string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NotificationPreference turns a notification channel on or off for a
+// user, channels without a preference are enabled.
+type NotificationPreference struct {
+	UserID    uuid.UUID
+	Channel   string
+	Enabled   bool
+	UpdatedAt time.Time
+}
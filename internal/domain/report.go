@@ -12,7 +12,155 @@ type Report struct {
 	IncidentType string
 	Longitude    string
 	Latitude     string
+	Lat          float64
+	Lng          float64
 	Description  string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	Status       string
+	// ConfirmationCount, DisputeCount and CredibilityScore are maintained by
+	// the repository whenever a vote is cast or retracted
+	ConfirmationCount int
+	DisputeCount      int
+	CredibilityScore  float64
+	Media             []ReportMedia
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	// DeletedAt is zero unless the owner has removed the report
+	DeletedAt time.Time
+	// IsAnonymous reports have no OwnerID, their owner can only be looked
+	// up by admins
+	IsAnonymous bool
+	// DuplicateOf is the primary report this report corroborates, it is
+	// uuid.Nil for primary reports
+	DuplicateOf uuid.UUID
+	// CorroborationCount is maintained by the repository whenever reports are
+	// linked to or unlinked from this report
+	CorroborationCount int
+	// StateCode and LGACode are the administrative areas the report was made
+	// in, they are empty when it is outside every known area
+	StateCode string
+	LGACode   string
+	// HiddenAt is zero unless a moderator hid the report, hidden reports
+	// are left out of every listing
+	HiddenAt time.Time
+	// ClientID is the id the app gave a report it queued offline, it is
+	// uuid.Nil for reports sent straight away
+	ClientID uuid.UUID
+	// OccurredAt is when the reporter says the incident happened, it is zero
+	// unless the app sent it
+	OccurredAt time.Time
+}
+
+func (r Report) IsDeleted() bool {
+	return !r.DeletedAt.IsZero()
+}
+
+func (r Report) IsHidden() bool {
+	return !r.HiddenAt.IsZero()
+}
+
+// IsVisible reports whether the report can be shown, deleted reports never
+// are and hidden reports only to moderators.
+func (r Report) IsVisible(isModerator bool) bool {
+	return !r.IsDeleted() && (!r.IsHidden() || isModerator)
+}
+
+// HappenedAt is when the incident happened, as far as we know.
+func (r Report) HappenedAt() time.Time {
+	if !r.OccurredAt.IsZero() {
+		return r.OccurredAt
+	}
+	return r.CreatedAt
+}
+
+func (r Report) IsCorroboration() bool {
+	return r.DuplicateOf != uuid.Nil
+}
+
+const (
+	ReportLinkCorroborate = "corroborate"
+	ReportLinkMerge       = "merge"
+	ReportLinkSplit       = "split"
+)
+
+// ReportLink records a report being attached to or detached from a primary
+// report, the reports themselves are never changed otherwise.
+type ReportLink struct {
+	ID       uuid.UUID
+	ReportID uuid.UUID
+	// PrimaryID is uuid.Nil when the report was split off
+	PrimaryID uuid.UUID
+	// PreviousPrimaryID is uuid.Nil when the report was a primary report
+	PreviousPrimaryID uuid.UUID
+	Action            string
+	// ActorID is uuid.Nil when an anonymous report corroborated another
+	ActorID   uuid.UUID
+	CreatedAt time.Time
+}
+
+type ReportSearchResult struct {
+	Report
+	Rank float64
+	// Snippet is an excerpt of the description with the matched terms
+	// wrapped in <mark> tags
+	Snippet string
+}
+
+type ReportMedia struct {
+	ReportID  uuid.UUID
+	FileID    uuid.UUID
+	OwnerID   uuid.UUID
+	Url       string
+	MimeType  string
+	Position  int
+	CreatedAt time.Time
+}
+
+const (
+	ReportStatusReported   = "reported"
+	ReportStatusVerified   = "verified"
+	ReportStatusResolved   = "resolved"
+	ReportStatusFalseAlarm = "false_alarm"
+	ReportStatusExpired    = "expired"
+)
+
+const (
+	VoteConfirm = "confirm"
+	VoteDispute = "dispute"
+)
+
+type ReportVote struct {
+	ReportID  uuid.UUID
+	UserID    uuid.UUID
+	Vote      string
+	CreatedAt time.Time
+}
+
+type ReportStatusChange struct {
+	ID         uuid.UUID
+	ReportID   uuid.UUID
+	FromStatus string
+	ToStatus   string
+	// ChangedBy is uuid.Nil when the change was made by the system or by
+	// the owner of an anonymous report
+	ChangedBy uuid.UUID
+	Note      string
+	CreatedAt time.Time
+}
+
+type NearbyReport struct {
+	Report
+	DistanceInMeters float64
+}
+
+type ReportCluster struct {
+	Latitude      float64
+	Longitude     float64
+	Count         int
+	IncidentTypes map[string]int
+}
+
+type MapView struct {
+	IsClustered bool
+	Reports     []Report
+	Clusters    []ReportCluster
 }
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type ReportEvent struct {
+	// ID increases with every event so clients can resume from the last one
+	// they received
+	ID        string
+	Type      string
+	Report    Report
+	CreatedAt time.Time
+}
+
+const (
+	ReportEventCreated = "report.created"
+	ReportEventUpdated = "report.updated"
+	ReportEventDeleted = "report.deleted"
+	// ReportEventExpired is sent when a report outlived the time to live of
+	// its incident type and left the live feeds
+	ReportEventExpired = "report.expired"
+)
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// ReportStats summarises the reports made between From and To. Counts for
+// the period of the same length right before From are included so that
+// periods can be compared.
+type ReportStats struct {
+	From          time.Time
+	To            time.Time
+	PreviousFrom  time.Time
+	TimeZone      string
+	Total         int
+	PreviousTotal int
+	// CellSizeInDegrees is the size of the square cells of ByCell
+	CellSizeInDegrees float64
+	ByIncidentType    []IncidentTypeCount
+	ByDay             []TimeBucketCount
+	ByWeek            []TimeBucketCount
+	// ByHourOfDay always has 24 entries, starting at midnight
+	ByHourOfDay []int
+	ByCell      []CellCount
+}
+
+type IncidentTypeCount struct {
+	IncidentType  string
+	Count         int
+	PreviousCount int
+}
+
+// TimeBucketCount counts the reports of a day or week starting at Start, in
+// the time zone of the stats.
+type TimeBucketCount struct {
+	Start time.Time
+	Count int
+}
+
+// CellCount counts the reports of a cell, Latitude and Longitude are the
+// center of the cell.
+type CellCount struct {
+	Latitude  float64
+	Longitude float64
+	Count     int
+}
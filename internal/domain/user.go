@@ -16,6 +16,23 @@ type User struct {
 	Password  string
 	Location  string
 	Phone     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Role      string
+	// HideReports keeps the user's report history private from other users
+	HideReports bool
+	// WarningCount is the number of warnings moderators gave the user
+	WarningCount int
+	// SuspendedUntil is zero unless a moderator suspended the user
+	SuspendedUntil time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
+
+func (u User) IsSuspended(now time.Time) bool {
+	return u.SuspendedUntil.After(now)
+}
+
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
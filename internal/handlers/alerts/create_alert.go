@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/usecases/alerts"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (ah AlertsHandler) CreateAlert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	request, err := response.Decode[AlertSubscriptionRequestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	newSubscription, err := ah.alertService.CreateSubscription(ctx, request.ToInput())
+	if err != nil {
+		switch {
+		case errors.Is(err, alerts.ErrTooManySubscriptions):
+			response.ErrorResponse(w, err.Error(), http.StatusConflict)
+			return
+		case isInvalidAlertInput(err):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ah.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "alert subscription created successfully", ToAlertSubscriptionDTO(newSubscription), ah.logger)
+}
+
+func isInvalidAlertInput(err error) bool {
+	for _, target := range []error{
+		alerts.ErrInvalidShape,
+		alerts.ErrInvalidCenter,
+		alerts.ErrInvalidRadius,
+		alerts.ErrInvalidPolygon,
+		alerts.ErrInvalidIncidentType,
+		alerts.ErrInvalidQuietHours,
+		alerts.ErrInvalidTimeZone,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
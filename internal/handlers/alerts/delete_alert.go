@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (ah AlertsHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	err = ah.alertService.DeleteSubscription(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrAlertSubscriptionNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ah.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "alert subscription deleted successfully", nil, ah.logger)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (ah AlertsHandler) GetMyAlerts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscriptions, err := ah.alertService.GetMySubscriptions(ctx)
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, ah.logger)
+		return
+	}
+
+	response.SuccessResponse(w, "alert subscriptions retrieved successfully", ToAlertSubscriptionsDTO(subscriptions), ah.logger)
+}
+
+func (ah AlertsHandler) GetAlert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := ah.alertService.GetSubscription(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrAlertSubscriptionNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ah.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "alert subscription retrieved successfully", ToAlertSubscriptionDTO(subscription), ah.logger)
+}
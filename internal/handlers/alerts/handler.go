@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/usecases/alerts"
+	"go.uber.org/zap"
+)
+
+type AlertsHandler struct {
+	alertService alerts.AlertService
+	logger       *zap.Logger
+}
+
+func NewAlertsHandler(alertService alerts.AlertService, logger *zap.Logger) (*AlertsHandler, error) {
+	if alertService == (alerts.AlertService{}) {
+		return nil, errors.New("alert service cannot be empty")
+	}
+
+	return &AlertsHandler{alertService, logger}, nil
+}
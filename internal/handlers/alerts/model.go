@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/usecases/alerts"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+type PointDTO struct {
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
+}
+
+type QuietHoursDTO struct {
+	Start string `json:"start" validate:"required"`
+	End   string `json:"end" validate:"required"`
+}
+
+// AlertSubscriptionRequestDTO is shared by create and update, center and
+// radius_in_meters are used for circles and polygon for polygons.
+type AlertSubscriptionRequestDTO struct {
+	Name           string         `json:"name" validate:"required,max=50"`
+	Shape          string         `json:"shape" validate:"required,oneof=circle polygon"`
+	Center         *PointDTO      `json:"center" validate:"required_if=Shape circle"`
+	RadiusInMeters float64        `json:"radius_in_meters"`
+	Polygon        []PointDTO     `json:"polygon" validate:"required_if=Shape polygon,dive"`
+	IncidentTypes  []string       `json:"incident_types" validate:"max=20"`
+	QuietHours     *QuietHoursDTO `json:"quiet_hours"`
+	TimeZone       string         `json:"time_zone" validate:"max=64"`
+}
+
+func (a AlertSubscriptionRequestDTO) ToInput() alerts.AlertSubscriptionInput {
+	input := alerts.AlertSubscriptionInput{
+		Name:           a.Name,
+		Shape:          a.Shape,
+		RadiusInMeters: a.RadiusInMeters,
+		IncidentTypes:  a.IncidentTypes,
+		TimeZone:       a.TimeZone,
+	}
+	if a.Center != nil {
+		input.Latitude = a.Center.Latitude
+		input.Longitude = a.Center.Longitude
+	}
+	for _, point := range a.Polygon {
+		input.Polygon = append(input.Polygon, geo.Point{Latitude: point.Latitude, Longitude: point.Longitude})
+	}
+	if a.QuietHours != nil {
+		input.QuietHoursStart = a.QuietHours.Start
+		input.QuietHoursEnd = a.QuietHours.End
+	}
+	return input
+}
+
+type AlertSubscriptionDTO struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Shape          string         `json:"shape"`
+	Center         *PointDTO      `json:"center,omitempty"`
+	RadiusInMeters float64        `json:"radius_in_meters,omitempty"`
+	Polygon        []PointDTO     `json:"polygon,omitempty"`
+	IncidentTypes  []string       `json:"incident_types"`
+	QuietHours     *QuietHoursDTO `json:"quiet_hours"`
+	TimeZone       string         `json:"time_zone"`
+	CreatedAt      *time.Time     `json:"created_at"`
+	UpdatedAt      *time.Time     `json:"updated_at"`
+}
+
+func ToAlertSubscriptionDTO(subscription domain.AlertSubscription) AlertSubscriptionDTO {
+	result := AlertSubscriptionDTO{
+		ID:            subscription.ID.String(),
+		Name:          subscription.Name,
+		Shape:         subscription.Shape,
+		IncidentTypes: subscription.IncidentTypes,
+		TimeZone:      subscription.TimeZone,
+		CreatedAt:     &subscription.CreatedAt,
+		UpdatedAt:     &subscription.UpdatedAt,
+	}
+	if result.IncidentTypes == nil {
+		result.IncidentTypes = []string{}
+	}
+
+	switch subscription.Shape {
+	case domain.AlertShapeCircle:
+		result.Center = &PointDTO{Latitude: subscription.Latitude, Longitude: subscription.Longitude}
+		result.RadiusInMeters = subscription.RadiusInMeters
+	case domain.AlertShapePolygon:
+		for _, point := range subscription.Polygon {
+			result.Polygon = append(result.Polygon, PointDTO{Latitude: point.Latitude, Longitude: point.Longitude})
+		}
+	}
+
+	if subscription.HasQuietHours() {
+		result.QuietHours = &QuietHoursDTO{
+			Start: alerts.FormatTimeOfDay(subscription.QuietHoursStart),
+			End:   alerts.FormatTimeOfDay(subscription.QuietHoursEnd),
+		}
+	}
+	return result
+}
+
+type AlertSubscriptionsDTO struct {
+	Rows int `json:"rows"`
+	// Limit is how many subscriptions a user is allowed to have
+	Limit int                    `json:"limit"`
+	Items []AlertSubscriptionDTO `json:"items"`
+}
+
+func ToAlertSubscriptionsDTO(subscriptions []domain.AlertSubscription) AlertSubscriptionsDTO {
+	items := []AlertSubscriptionDTO{}
+	for _, subscription := range subscriptions {
+		items = append(items, ToAlertSubscriptionDTO(subscription))
+	}
+	return AlertSubscriptionsDTO{
+		Rows:  len(items),
+		Limit: alerts.MaxSubscriptionsPerUser,
+		Items: items,
+	}
+}
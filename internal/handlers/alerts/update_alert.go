@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (ah AlertsHandler) UpdateAlert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	request, err := response.Decode[AlertSubscriptionRequestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedSubscription, err := ah.alertService.UpdateSubscription(ctx, id, request.ToInput())
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrAlertSubscriptionNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case isInvalidAlertInput(err):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ah.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "alert subscription updated successfully", ToAlertSubscriptionDTO(updatedSubscription), ah.logger)
+}
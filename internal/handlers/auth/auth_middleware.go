@@ -31,3 +31,37 @@ func EnsureAuthenticated(authService auth.AuthService) func(next http.Handler) h
 		})
 	}
 }
+
+// EnsureRole must be mounted after EnsureAuthenticated.
+func EnsureRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			jwtClaims, ok := auth.GetJWTClaims(r.Context())
+			if !ok {
+				response.ErrorResponse(w, appErrors.ErrUnauthorized, http.StatusUnauthorized)
+				return
+			}
+
+			if !jwtClaims.HasRole(roles...) {
+				response.ErrorResponse(w, appErrors.ErrForbidden, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AccessTokenFromQuery lets clients that cannot set request headers, such as
+// browser EventSource and WebSocket clients, send their access token as a
+// query parameter. It must be mounted before EnsureAuthenticated.
+func AccessTokenFromQuery(key string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := r.URL.Query().Get(key); token != "" && r.Header.Get("Authorization") == "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/comments"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (ch CommentsHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Body     string `json:"body" validate:"required,max=1000"`
+		ParentID string `json:"parent_id" validate:"omitempty,uuid"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	parentId := uuid.Nil
+	if request.ParentID != "" {
+		parentId = uuid.MustParse(request.ParentID)
+	}
+
+	newComment, err := ch.commentService.CreateComment(ctx, reportId, parentId, request.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, comments.ErrInvalidParentComment):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ch.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "comment created successfully", ToCommentDTO(newComment), ch.logger)
+}
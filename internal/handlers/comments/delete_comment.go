@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/comments"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (ch CommentsHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+	commentId, err := uuid.Parse(chi.URLParam(r, "commentId"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	err = ch.commentService.DeleteComment(ctx, reportId, commentId)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrCommentNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, comments.ErrNotCommentAuthor):
+			response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ch.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "comment deleted successfully", nil, ch.logger)
+}
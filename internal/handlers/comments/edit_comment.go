@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/comments"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (ch CommentsHandler) EditComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+	commentId, err := uuid.Parse(chi.URLParam(r, "commentId"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Body string `json:"body" validate:"required,max=1000"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedComment, err := ch.commentService.EditComment(ctx, reportId, commentId, request.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrCommentNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, comments.ErrNotCommentAuthor):
+			response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ch.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "comment updated successfully", ToCommentDTO(updatedComment), ch.logger)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (ch CommentsHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		apiUtils.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	pageInfo, err := apiUtils.ParseRequest(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	threads, err := ch.commentService.GetComments(ctx, reportId, pageInfo.Number, pageInfo.RowsPerPage)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, ch.logger)
+			return
+		}
+	}
+
+	apiUtils.SuccessResponse(w, "comments retrieved successfully", ToCommentsPagedDTO(threads, pageInfo.Number), ch.logger)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/usecases/comments"
+	"go.uber.org/zap"
+)
+
+type CommentsHandler struct {
+	commentService comments.CommentService
+	logger         *zap.Logger
+}
+
+func NewCommentsHandler(commentService comments.CommentService, logger *zap.Logger) (*CommentsHandler, error) {
+	if commentService == (comments.CommentService{}) {
+		return nil, errors.New("comment service cannot be empty")
+	}
+
+	return &CommentsHandler{commentService, logger}, nil
+}
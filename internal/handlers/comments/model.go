@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+)
+
+type CommentDTO struct {
+	ID        string       `json:"id"`
+	ReportID  string       `json:"report_id"`
+	AuthorID  string       `json:"author_id"`
+	ParentID  *string      `json:"parent_id"`
+	Body      string       `json:"body"`
+	Replies   []CommentDTO `json:"replies,omitempty"`
+	CreatedAt *time.Time   `json:"created_at"`
+	UpdatedAt *time.Time   `json:"updated_at"`
+}
+
+func ToCommentDTO(comment domain.Comment) CommentDTO {
+	result := CommentDTO{
+		ID:        comment.ID.String(),
+		ReportID:  comment.ReportID.String(),
+		AuthorID:  comment.AuthorID.String(),
+		Body:      comment.Body,
+		CreatedAt: &comment.CreatedAt,
+		UpdatedAt: &comment.UpdatedAt,
+	}
+	if comment.ParentID != uuid.Nil {
+		parentId := comment.ParentID.String()
+		result.ParentID = &parentId
+	}
+	return result
+}
+
+type CommentsPagedDTO struct {
+	Rows  int          `json:"rows"`
+	Page  int          `json:"page"`
+	Items []CommentDTO `json:"items"`
+}
+
+func ToCommentsPagedDTO(threads []domain.CommentThread, page int) CommentsPagedDTO {
+	items := []CommentDTO{}
+	for _, thread := range threads {
+		item := ToCommentDTO(thread.Comment)
+		for _, reply := range thread.Replies {
+			item.Replies = append(item.Replies, ToCommentDTO(reply))
+		}
+		items = append(items, item)
+	}
+	return CommentsPagedDTO{
+		Page:  page,
+		Rows:  len(items),
+		Items: items,
+	}
+}
@@ -25,7 +25,7 @@ func (f FilesHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	defer file.Close()
 
 	ctx := r.Context()
-	url, err := f.fileService.UploadFile(ctx, file)
+	uploadedFile, err := f.fileService.UploadFile(ctx, file)
 	if err != nil {
 		switch {
 		case errors.Is(err, files.ErrInvalidFileType):
@@ -38,7 +38,9 @@ func (f FilesHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 	response.SuccessResponse(w, "image uploaded successfully",
 		map[string]interface{}{
-			"url": url,
+			"id":        uploadedFile.ID.String(),
+			"url":       uploadedFile.Url,
+			"mime_type": uploadedFile.MimeType,
 		},
 		f.logger)
 }
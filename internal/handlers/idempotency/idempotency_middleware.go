@@ -0,0 +1,230 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderName = "Idempotency-Key"
+	// ReplayedHeaderName is set on responses replayed from the cache
+	ReplayedHeaderName = "Idempotent-Replayed"
+
+	MaxKeyLength = 255
+	// MaxBodySize is larger than any request the idempotent routes accept
+	MaxBodySize = 1024 * 1024 * 4
+	// ReplayWindow is how long a response is replayed for its key
+	ReplayWindow = 24 * time.Hour
+	// inFlightTTL frees the key of a request that never finished, such as
+	// when the server stopped while handling it
+	inFlightTTL = time.Minute
+)
+
+const (
+	ErrKeyTooLong   = "Idempotency-Key must be at most 255 characters"
+	ErrBodyTooLarge = "request body is too large"
+	ErrKeyReused    = "Idempotency-Key was already used for a different request"
+	ErrKeyInFlight  = "a request with this Idempotency-Key is still being processed, retry later"
+)
+
+// record is what is cached for a key, Status is zero while the first request
+// is still being handled.
+type record struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotent replays the response of the first request made with an
+// Idempotency-Key to retries of that request for ReplayWindow, so clients on
+// flaky networks can retry without creating duplicates. Reusing a key for a
+// different request fails with 422. Requests without the header are passed
+// through.
+//
+// Keys are scoped to the authenticated user when there is one, so the
+// middleware must be mounted after EnsureAuthenticated on routes that need
+// it. Server errors and rate limited responses are not kept, so they can be
+// retried with the same key.
+func Idempotent(cache infra.Cache, l *zap.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderName)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > MaxKeyLength {
+				response.ErrorResponse(w, ErrKeyTooLong, http.StatusBadRequest)
+				return
+			}
+
+			ctx := r.Context()
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					response.ErrorResponse(w, ErrBodyTooLarge, http.StatusRequestEntityTooLarge)
+					return
+				}
+				response.InternalServerErrorResponse(w, err, l)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			cacheKey := cacheKeyFor(r, key)
+			fingerprint := fingerprintOf(r, body)
+			pending, err := json.Marshal(record{Fingerprint: fingerprint})
+			if err != nil {
+				response.InternalServerErrorResponse(w, err, l)
+				return
+			}
+
+			claimed, err := cache.SetOneIfNotExists(ctx, cacheKey, string(pending), inFlightTTL)
+			if err != nil {
+				response.InternalServerErrorResponse(w, err, l)
+				return
+			}
+			if !claimed {
+				replay(w, cache, cacheKey, fingerprint, r, l)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			handled := false
+			defer func() {
+				// frees the key when the handler panics
+				if !handled {
+					releaseKey(cache, cacheKey, l)
+				}
+			}()
+			next.ServeHTTP(recorder, r)
+			handled = true
+
+			if recorder.status >= http.StatusInternalServerError || recorder.status == http.StatusTooManyRequests {
+				releaseKey(cache, cacheKey, l)
+				return
+			}
+			stored, err := json.Marshal(record{
+				Fingerprint: fingerprint,
+				Status:      recorder.status,
+				ContentType: recorder.Header().Get("Content-Type"),
+				Body:        recorder.body.Bytes(),
+			})
+			if err == nil {
+				err = cache.SetOne(ctx, cacheKey, string(stored), ReplayWindow)
+			}
+			// the in flight record is kept so retries do not repeat the
+			// request while it lasts
+			if err != nil {
+				l.Error("error storing idempotent response", zap.Error(err))
+			}
+		})
+	}
+}
+
+func releaseKey(cache infra.Cache, cacheKey string, l *zap.Logger) {
+	if err := cache.DeleteOne(context.Background(), cacheKey); err != nil {
+		l.Error("error releasing idempotency key", zap.Error(err))
+	}
+}
+
+func replay(w http.ResponseWriter, cache infra.Cache, cacheKey, fingerprint string, r *http.Request, l *zap.Logger) {
+	cached, err := cache.GetOne(r.Context(), cacheKey)
+	if errors.Is(err, infra.ErrCacheMiss) {
+		// the key expired between the two calls
+		response.ErrorResponse(w, ErrKeyInFlight, http.StatusConflict)
+		return
+	}
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, l)
+		return
+	}
+	var previous record
+	if err := json.Unmarshal([]byte(cached), &previous); err != nil {
+		response.InternalServerErrorResponse(w, err, l)
+		return
+	}
+
+	switch {
+	case previous.Fingerprint != fingerprint:
+		response.ErrorResponse(w, ErrKeyReused, http.StatusUnprocessableEntity)
+	case previous.Status == 0:
+		response.ErrorResponse(w, ErrKeyInFlight, http.StatusConflict)
+	default:
+		if previous.ContentType != "" {
+			w.Header().Set("Content-Type", previous.ContentType)
+		}
+		w.Header().Set(ReplayedHeaderName, "true")
+		w.WriteHeader(previous.Status)
+		if _, err := w.Write(previous.Body); err != nil {
+			l.Error("error replaying idempotent response", zap.Error(err))
+		}
+	}
+}
+
+// cacheKeyFor scopes the key to the route and the user making the request.
+func cacheKeyFor(r *http.Request, key string) string {
+	scope := "anonymous"
+	if jwtClaims, ok := auth.GetJWTClaims(r.Context()); ok {
+		scope = jwtClaims.ID.String()
+	}
+	sum := sha256.Sum256([]byte(scope + "\n" + r.Method + " " + r.URL.Path + "\n" + key))
+	return "idempotency:" + hex.EncodeToString(sum[:])
+}
+
+// fingerprintOf leaves out the multipart boundary, clients pick a new one
+// each time they build the request.
+func fingerprintOf(r *http.Request, body []byte) string {
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+		if boundary := params["boundary"]; boundary != "" {
+			body = bytes.ReplaceAll(body, []byte(boundary), nil)
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n" + contentType + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(code int) {
+	if !rr.wroteHeader {
+		rr.status = code
+		rr.wroteHeader = true
+	}
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
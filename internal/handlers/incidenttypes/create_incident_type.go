@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/usecases/incidenttypes"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (ih IncidentTypesHandler) CreateIncidentType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Slug            string `json:"slug" validate:"required,max=50"`
+		DisplayName     string `json:"display_name" validate:"required,max=100"`
+		DefaultSeverity int    `json:"default_severity" validate:"required,min=1,max=5"`
+		Icon            string `json:"icon" validate:"omitempty,max=255"`
+		AllowsAnonymous bool   `json:"allows_anonymous"`
+		TTLHours        *int   `json:"ttl_hours" validate:"omitempty,min=0,max=8760"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	newIncidentType, err := ih.incidentTypeService.CreateIncidentType(
+		ctx,
+		request.Slug,
+		request.DisplayName,
+		request.DefaultSeverity,
+		request.Icon,
+		request.AllowsAnonymous,
+		toTimeToLive(request.TTLHours))
+	if err != nil {
+		switch {
+		case errors.Is(err, incidenttypes.ErrInvalidSlug):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, incidenttypes.ErrIncidentTypeAlreadyExists):
+			response.ErrorResponse(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ih.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "incident type created successfully", ToIncidentTypeDTO(newIncidentType), ih.logger)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	response "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (ih IncidentTypesHandler) GetIncidentTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	incidentTypes, err := ih.incidentTypeService.GetActiveIncidentTypes(ctx)
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, ih.logger)
+		return
+	}
+
+	response.SuccessResponse(w, "incident types retrieved successfully", ToIncidentTypeDTOs(incidentTypes), ih.logger)
+}
+
+func (ih IncidentTypesHandler) GetAllIncidentTypes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	incidentTypes, err := ih.incidentTypeService.GetAllIncidentTypes(ctx)
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, ih.logger)
+		return
+	}
+
+	response.SuccessResponse(w, "incident types retrieved successfully", ToIncidentTypeDTOs(incidentTypes), ih.logger)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/usecases/incidenttypes"
+	"go.uber.org/zap"
+)
+
+type IncidentTypesHandler struct {
+	incidentTypeService incidenttypes.IncidentTypeService
+	logger              *zap.Logger
+}
+
+func NewIncidentTypesHandler(incidentTypeService incidenttypes.IncidentTypeService, logger *zap.Logger) (*IncidentTypesHandler, error) {
+	if incidentTypeService == (incidenttypes.IncidentTypeService{}) {
+		return nil, errors.New("incident type service cannot be empty")
+	}
+
+	return &IncidentTypesHandler{incidentTypeService, logger}, nil
+}
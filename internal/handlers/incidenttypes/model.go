@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+)
+
+type IncidentTypeDTO struct {
+	Slug            string     `json:"slug"`
+	DisplayName     string     `json:"display_name"`
+	DefaultSeverity int        `json:"default_severity"`
+	Icon            string     `json:"icon"`
+	IsActive        bool       `json:"is_active"`
+	AllowsAnonymous bool       `json:"allows_anonymous"`
+	TTLHours        int        `json:"ttl_hours"`
+	CreatedAt       *time.Time `json:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+func ToIncidentTypeDTO(incidentType domain.IncidentType) IncidentTypeDTO {
+	return IncidentTypeDTO{
+		Slug:            incidentType.Slug,
+		DisplayName:     incidentType.DisplayName,
+		DefaultSeverity: incidentType.DefaultSeverity,
+		Icon:            incidentType.Icon,
+		IsActive:        incidentType.IsActive,
+		AllowsAnonymous: incidentType.AllowsAnonymous,
+		TTLHours:        int(incidentType.TimeToLive / time.Hour),
+		CreatedAt:       &incidentType.CreatedAt,
+		UpdatedAt:       &incidentType.UpdatedAt,
+	}
+}
+
+func ToIncidentTypeDTOs(incidentTypes []domain.IncidentType) []IncidentTypeDTO {
+	items := []IncidentTypeDTO{}
+	for _, incidentType := range incidentTypes {
+		items = append(items, ToIncidentTypeDTO(incidentType))
+	}
+	return items
+}
+
+// toTimeToLive falls back to the default when ttl_hours is not given, 0
+// keeps reports live forever.
+func toTimeToLive(ttlHours *int) time.Duration {
+	if ttlHours == nil {
+		return domain.DefaultTimeToLive
+	}
+	return time.Duration(*ttlHours) * time.Hour
+}
+
+// toOptionalTimeToLive is nil when ttl_hours is not given, so updates keep
+// the time to live already set.
+func toOptionalTimeToLive(ttlHours *int) *time.Duration {
+	if ttlHours == nil {
+		return nil
+	}
+	timeToLive := toTimeToLive(ttlHours)
+	return &timeToLive
+}
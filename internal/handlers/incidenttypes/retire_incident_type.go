@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/olad5/caution-companion/internal/infra"
+	response "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (ih IncidentTypesHandler) RetireIncidentType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := ih.incidentTypeService.RetireIncidentType(ctx, chi.URLParam(r, "slug"))
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrIncidentTypeNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ih.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "incident type retired successfully", nil, ih.logger)
+}
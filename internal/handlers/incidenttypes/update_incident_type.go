@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/olad5/caution-companion/internal/infra"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (ih IncidentTypesHandler) UpdateIncidentType(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		DisplayName     string `json:"display_name" validate:"required,max=100"`
+		DefaultSeverity int    `json:"default_severity" validate:"required,min=1,max=5"`
+		Icon            string `json:"icon" validate:"omitempty,max=255"`
+		IsActive        *bool  `json:"is_active" validate:"required"`
+		AllowsAnonymous *bool  `json:"allows_anonymous"`
+		TTLHours        *int   `json:"ttl_hours" validate:"omitempty,min=0,max=8760"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedIncidentType, err := ih.incidentTypeService.UpdateIncidentType(
+		ctx,
+		chi.URLParam(r, "slug"),
+		request.DisplayName,
+		request.DefaultSeverity,
+		request.Icon,
+		*request.IsActive,
+		request.AllowsAnonymous,
+		toOptionalTimeToLive(request.TTLHours))
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrIncidentTypeNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, ih.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "incident type updated successfully", ToIncidentTypeDTO(updatedIncidentType), ih.logger)
+}
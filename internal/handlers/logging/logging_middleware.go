@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/olad5/caution-companion/config"
@@ -14,6 +15,10 @@ import (
 
 type contextKey string
 
+// sensitiveQueryParams are redacted from logged urls, streaming clients send
+// their access token as a query parameter.
+var sensitiveQueryParams = []string{"access_token"}
+
 const (
 	correlationIDCtxKey contextKey = "correlation_id"
 )
@@ -32,6 +37,12 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, streaming
+// endpoints need it to flush.
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func RequestLogger(next http.Handler, cfg *config.Configurations) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		l := logger.Get(cfg)
@@ -55,14 +66,15 @@ func RequestLogger(next http.Handler, cfg *config.Configurations) http.Handler {
 		r = r.WithContext(logger.WithCtx(ctx, l))
 
 		defer func(start time.Time) {
+			requestURI := redactedRequestURI(r.URL)
 			l.Info(
 				fmt.Sprintf(
 					"%s request to %s completed",
 					r.Method,
-					r.RequestURI,
+					requestURI,
 				),
 				zap.String("method", r.Method),
-				zap.String("url", r.RequestURI),
+				zap.String("url", requestURI),
 				zap.String("user_agent", r.UserAgent()),
 				zap.Int("status_code", lrw.statusCode),
 				zap.Duration("elapsed_ms", time.Since(start)),
@@ -72,3 +84,21 @@ func RequestLogger(next http.Handler, cfg *config.Configurations) http.Handler {
 		next.ServeHTTP(lrw, r)
 	})
 }
+
+func redactedRequestURI(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, key := range sensitiveQueryParams {
+		if query.Has(key) {
+			query.Set(key, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.RequestURI()
+	}
+
+	withoutSecrets := *u
+	withoutSecrets.RawQuery = query.Encode()
+	return withoutSecrets.RequestURI()
+}
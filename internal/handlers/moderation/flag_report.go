@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/moderation"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (mh ModerationHandler) FlagReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Reason string `json:"reason" validate:"required"`
+		Note   string `json:"note"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flag, err := mh.moderationService.FlagReport(ctx, reportId, request.Reason, request.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, moderation.ErrInvalidFlagReason),
+			errors.Is(err, moderation.ErrFlagNoteTooLong),
+			errors.Is(err, moderation.ErrFlagOwnReport):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, infra.ErrReportAlreadyFlagged):
+			response.ErrorResponse(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, mh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "report flagged successfully", ToReportFlagDTO(flag), mh.logger)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	reportHandlers "github.com/olad5/caution-companion/internal/handlers/reports"
+	"github.com/olad5/caution-companion/internal/usecases/moderation"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (mh ModerationHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pageInfo, err := apiUtils.ParseRequest(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	queue, total, err := mh.moderationService.GetModerationQueue(ctx, pageInfo.Number, pageInfo.RowsPerPage)
+	if err != nil {
+		switch {
+		case errors.Is(err, moderation.ErrModeratorsOnly):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, mh.logger)
+			return
+		}
+	}
+
+	apiUtils.SuccessResponse(w, "moderation queue retrieved successfully",
+		ToModerationQueueDTO(queue, total, pageInfo.Number, reportHandlers.ViewerFromContext(r.Context())), mh.logger)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/usecases/moderation"
+	"go.uber.org/zap"
+)
+
+type ModerationHandler struct {
+	moderationService moderation.ModerationService
+	logger            *zap.Logger
+}
+
+func NewModerationHandler(moderationService moderation.ModerationService, logger *zap.Logger) (*ModerationHandler, error) {
+	if moderationService == (moderation.ModerationService{}) {
+		return nil, errors.New("moderation service cannot be empty")
+	}
+
+	return &ModerationHandler{moderationService, logger}, nil
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	reportHandlers "github.com/olad5/caution-companion/internal/handlers/reports"
+)
+
+type ReportFlagDTO struct {
+	ID        string     `json:"id"`
+	ReportID  string     `json:"report_id"`
+	Reason    string     `json:"reason"`
+	Note      string     `json:"note"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func ToReportFlagDTO(flag domain.ReportFlag) ReportFlagDTO {
+	return ReportFlagDTO{
+		ID:        flag.ID.String(),
+		ReportID:  flag.ReportID.String(),
+		Reason:    flag.Reason,
+		Note:      flag.Note,
+		CreatedAt: &flag.CreatedAt,
+	}
+}
+
+type FlaggedReportDTO struct {
+	Report        reportHandlers.ReportDTO `json:"report"`
+	PendingFlags  int                      `json:"pending_flags"`
+	LastFlaggedAt *time.Time               `json:"last_flagged_at"`
+	Reasons       map[string]int           `json:"reasons"`
+}
+
+type ModerationQueueDTO struct {
+	Rows  int                `json:"rows"`
+	Page  int                `json:"page"`
+	Total int                `json:"total"`
+	Items []FlaggedReportDTO `json:"items"`
+}
+
+func ToModerationQueueDTO(
+	queue []domain.FlaggedReport, total, page int, viewer reportHandlers.Viewer,
+) ModerationQueueDTO {
+	items := []FlaggedReportDTO{}
+	for _, entry := range queue {
+		items = append(items, FlaggedReportDTO{
+			Report:        reportHandlers.ToReportDTO(entry.Report, viewer),
+			PendingFlags:  entry.PendingFlags,
+			LastFlaggedAt: &entry.LastFlaggedAt,
+			Reasons:       entry.Reasons,
+		})
+	}
+	return ModerationQueueDTO{
+		Page:  page,
+		Rows:  len(items),
+		Total: total,
+		Items: items,
+	}
+}
+
+type ModerationActionDTO struct {
+	ID          string  `json:"id"`
+	ModeratorID string  `json:"moderator_id"`
+	Action      string  `json:"action"`
+	ReportID    *string `json:"report_id"`
+	// UserID is null when the moderator may not know who made an anonymous
+	// report
+	UserID         *string    `json:"user_id"`
+	Reason         string     `json:"reason"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	CreatedAt      *time.Time `json:"created_at"`
+}
+
+func ToModerationActionDTO(action domain.ModerationAction) ModerationActionDTO {
+	result := ModerationActionDTO{
+		ID:          action.ID.String(),
+		ModeratorID: action.ModeratorID.String(),
+		Action:      action.Action,
+		Reason:      action.Reason,
+		CreatedAt:   &action.CreatedAt,
+	}
+	if action.ReportID != uuid.Nil {
+		reportId := action.ReportID.String()
+		result.ReportID = &reportId
+	}
+	if action.UserID != uuid.Nil {
+		userId := action.UserID.String()
+		result.UserID = &userId
+	}
+	if !action.SuspendedUntil.IsZero() {
+		result.SuspendedUntil = &action.SuspendedUntil
+	}
+	return result
+}
+
+func ToModerationActionDTOs(actions []domain.ModerationAction) []ModerationActionDTO {
+	items := []ModerationActionDTO{}
+	for _, action := range actions {
+		items = append(items, ToModerationActionDTO(action))
+	}
+	return items
+}
+
+type ContentFindingDTO struct {
+	ID        string     `json:"id"`
+	Rule      string     `json:"rule"`
+	Language  string     `json:"language,omitempty"`
+	Action    string     `json:"action"`
+	Match     string     `json:"match"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func ToContentFindingDTOs(findings []domain.ContentFinding) []ContentFindingDTO {
+	items := []ContentFindingDTO{}
+	for _, finding := range findings {
+		items = append(items, ContentFindingDTO{
+			ID:        finding.ID.String(),
+			Rule:      finding.Rule,
+			Language:  finding.Language,
+			Action:    finding.Action,
+			Match:     finding.Match,
+			CreatedAt: &finding.CreatedAt,
+		})
+	}
+	return items
+}
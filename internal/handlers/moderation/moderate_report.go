@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	reportHandlers "github.com/olad5/caution-companion/internal/handlers/reports"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/moderation"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+type moderationRequestDTO struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+	Days   int    `json:"days"`
+}
+
+func (mh ModerationHandler) HideReport(w http.ResponseWriter, r *http.Request) {
+	mh.moderateReport(w, r, "report hidden successfully", mh.moderationService.HideReport)
+}
+
+func (mh ModerationHandler) RestoreReport(w http.ResponseWriter, r *http.Request) {
+	mh.moderateReport(w, r, "report restored successfully", mh.moderationService.RestoreReport)
+}
+
+func (mh ModerationHandler) DeleteReport(w http.ResponseWriter, r *http.Request) {
+	mh.moderateReport(w, r, "report deleted successfully", mh.moderationService.DeleteReport)
+}
+
+func (mh ModerationHandler) WarnReporter(w http.ResponseWriter, r *http.Request) {
+	reportId, request, ok := parseModerationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	action, err := mh.moderationService.WarnReporter(r.Context(), reportId, request.Reason)
+	if err != nil {
+		mh.moderationErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "reporter warned successfully", ToModerationActionDTO(action), mh.logger)
+}
+
+func (mh ModerationHandler) SuspendReporter(w http.ResponseWriter, r *http.Request) {
+	reportId, request, ok := parseModerationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	action, err := mh.moderationService.SuspendReporter(r.Context(), reportId, request.Reason, request.Days)
+	if err != nil {
+		mh.moderationErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "reporter suspended successfully", ToModerationActionDTO(action), mh.logger)
+}
+
+func (mh ModerationHandler) GetModerationActions(w http.ResponseWriter, r *http.Request) {
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	actions, err := mh.moderationService.GetModerationActions(r.Context(), reportId)
+	if err != nil {
+		mh.moderationErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "moderation actions retrieved successfully", ToModerationActionDTOs(actions), mh.logger)
+}
+
+func (mh ModerationHandler) GetContentFindings(w http.ResponseWriter, r *http.Request) {
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	findings, err := mh.moderationService.GetContentFindings(r.Context(), reportId)
+	if err != nil {
+		mh.moderationErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "content findings retrieved successfully", ToContentFindingDTOs(findings), mh.logger)
+}
+
+func (mh ModerationHandler) moderateReport(
+	w http.ResponseWriter,
+	r *http.Request,
+	message string,
+	apply func(ctx context.Context, reportId uuid.UUID, reason string) (domain.Report, error),
+) {
+	reportId, request, ok := parseModerationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := apply(r.Context(), reportId, request.Reason)
+	if err != nil {
+		mh.moderationErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, message, reportHandlers.ToReportDTO(report, reportHandlers.ViewerFromContext(r.Context())), mh.logger)
+}
+
+func parseModerationRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, moderationRequestDTO, bool) {
+	reportId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return uuid.Nil, moderationRequestDTO{}, false
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return uuid.Nil, moderationRequestDTO{}, false
+	}
+
+	request, err := response.Decode[moderationRequestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return uuid.Nil, moderationRequestDTO{}, false
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return uuid.Nil, moderationRequestDTO{}, false
+	}
+	return reportId, request, true
+}
+
+func (mh ModerationHandler) moderationErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, infra.ErrReportNotFound),
+		errors.Is(err, infra.ErrUserNotFound):
+		response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, moderation.ErrModeratorsOnly),
+		errors.Is(err, moderation.ErrCannotModerateStaff):
+		response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, moderation.ErrReportAlreadyHidden):
+		response.ErrorResponse(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, moderation.ErrInvalidSuspension):
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+	default:
+		response.InternalServerErrorResponse(w, err, mh.logger)
+	}
+}
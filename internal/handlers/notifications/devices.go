@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/notifications"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (nh NotificationsHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type keys struct {
+		P256dh string `json:"p256dh" validate:"required,max=200"`
+		Auth   string `json:"auth" validate:"required,max=100"`
+	}
+
+	type requestDTO struct {
+		Platform string `json:"platform" validate:"required"`
+		Token    string `json:"token" validate:"required,url,max=1000"`
+		Keys     keys   `json:"keys" validate:"required"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	device, err := nh.notificationService.RegisterDevice(
+		ctx, request.Platform, request.Token, request.Keys.P256dh, request.Keys.Auth, r.UserAgent())
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrTooManyDevices):
+			response.ErrorResponse(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, notifications.ErrInvalidPlatform),
+			errors.Is(err, notifications.ErrInvalidDeviceToken),
+			errors.Is(err, notifications.ErrMissingDeviceKeys):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, nh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "device registered successfully", ToDeviceDTO(device), nh.logger)
+}
+
+func (nh NotificationsHandler) GetMyDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	devices, err := nh.notificationService.GetMyDevices(ctx)
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, nh.logger)
+		return
+	}
+
+	response.SuccessResponse(w, "devices retrieved successfully", ToDevicesDTO(devices), nh.logger)
+}
+
+func (nh NotificationsHandler) RemoveDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	err = nh.notificationService.RemoveDevice(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrDeviceNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, nh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "device removed successfully", nil, nh.logger)
+}
+
+func (nh NotificationsHandler) GetWebPushKey(w http.ResponseWriter, r *http.Request) {
+	key, err := nh.notificationService.WebPushKey()
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	response.SuccessResponse(w, "web push key retrieved successfully", map[string]string{"public_key": key}, nh.logger)
+}
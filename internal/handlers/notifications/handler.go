@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/usecases/notifications"
+	"go.uber.org/zap"
+)
+
+type NotificationsHandler struct {
+	notificationService notifications.NotificationService
+	logger              *zap.Logger
+}
+
+func NewNotificationsHandler(notificationService notifications.NotificationService, logger *zap.Logger) (*NotificationsHandler, error) {
+	if notificationService == (notifications.NotificationService{}) {
+		return nil, errors.New("notification service cannot be empty")
+	}
+
+	return &NotificationsHandler{notificationService, logger}, nil
+}
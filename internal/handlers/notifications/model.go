@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+)
+
+// DeviceDTO leaves out the keys, they are only needed to encrypt pushes.
+type DeviceDTO struct {
+	ID        string     `json:"id"`
+	Platform  string     `json:"platform"`
+	Token     string     `json:"token"`
+	UserAgent string     `json:"user_agent"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func ToDeviceDTO(device domain.Device) DeviceDTO {
+	return DeviceDTO{
+		ID:        device.ID.String(),
+		Platform:  device.Platform,
+		Token:     device.Token,
+		UserAgent: device.UserAgent,
+		CreatedAt: &device.CreatedAt,
+		UpdatedAt: &device.UpdatedAt,
+	}
+}
+
+type DevicesDTO struct {
+	Rows  int         `json:"rows"`
+	Items []DeviceDTO `json:"items"`
+}
+
+func ToDevicesDTO(devices []domain.Device) DevicesDTO {
+	items := []DeviceDTO{}
+	for _, device := range devices {
+		items = append(items, ToDeviceDTO(device))
+	}
+	return DevicesDTO{
+		Rows:  len(items),
+		Items: items,
+	}
+}
+
+type NotificationPreferencesDTO struct {
+	Channels map[string]bool `json:"channels"`
+}
+
+func ToNotificationPreferencesDTO(preferences []domain.NotificationPreference) NotificationPreferencesDTO {
+	channels := map[string]bool{}
+	for _, preference := range preferences {
+		channels[preference.Channel] = preference.Enabled
+	}
+	return NotificationPreferencesDTO{Channels: channels}
+}
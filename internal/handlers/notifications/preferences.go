@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/usecases/notifications"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (nh NotificationsHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	preferences, err := nh.notificationService.GetMyPreferences(ctx)
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, nh.logger)
+		return
+	}
+
+	response.SuccessResponse(w, "notification preferences retrieved successfully", ToNotificationPreferencesDTO(preferences), nh.logger)
+}
+
+func (nh NotificationsHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Channels map[string]bool `json:"channels" validate:"required,min=1"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	preferences, err := nh.notificationService.UpdatePreferences(ctx, request.Channels)
+	if err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrUnknownChannel):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, nh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "notification preferences updated successfully", ToNotificationPreferencesDTO(preferences), nh.logger)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	reportsHandlers "github.com/olad5/caution-companion/internal/handlers/reports"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	maxMessageBytes          = 4096
+	maxSubscriptionsPerConn  = 20
+	errTooManySubscriptions  = "too many subscriptions"
+	errMissingSubscriptionID = "subscription_id must have a value"
+	errUnknownMessageType    = "unknown message type"
+	errMissingLocation       = "location must have a value"
+)
+
+// Connect upgrades an authenticated request to a WebSocket session. The
+// session only receives events for the areas and incident types it has
+// subscribed to.
+func (rh RealtimeHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	server := websocket.Server{
+		// the JWT is checked before the upgrade, so the origin is not
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			conn.MaxPayloadBytes = maxMessageBytes
+			rh.serveSession(conn.Request().Context(), conn, r.URL.Query().Get("last_event_id"))
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+type session struct {
+	conn   *websocket.Conn
+	logger *zap.Logger
+	viewer reportsHandlers.Viewer
+
+	writeMu sync.Mutex
+
+	mu            sync.Mutex
+	subscriptions map[string]reports.ReportEventFilter
+	missed        []domain.ReportEvent
+}
+
+func (rh RealtimeHandler) serveSession(ctx context.Context, conn *websocket.Conn, lastEventID string) {
+	defer conn.Close()
+
+	subscription, missed, err := rh.realtimeService.Connect(ctx, lastEventID)
+	if err != nil {
+		rh.logger.Error("unable to start realtime session", zap.Error(err))
+		return
+	}
+	defer subscription.Close()
+
+	s := &session{
+		conn:          conn,
+		logger:        rh.logger,
+		viewer:        reportsHandlers.ViewerFromContext(ctx),
+		subscriptions: map[string]reports.ReportEventFilter{},
+		missed:        missed,
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		s.readMessages(ctx, rh)
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				// the server is shutting down or the session fell behind
+				s.send(serverMessage{Type: messageClose, Message: "connection closed by the server"})
+				return
+			}
+			s.sendEvent(event, s.matchingSubscriptions(event))
+		}
+	}
+}
+
+func (s *session) readMessages(ctx context.Context, rh RealtimeHandler) {
+	for {
+		var message clientMessage
+		if err := websocket.JSON.Receive(s.conn, &message); err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.logger.Debug("realtime session ended", zap.Error(err))
+			}
+			return
+		}
+
+		switch message.Type {
+		case messageSubscribe:
+			s.subscribe(message)
+		case messageUnsubscribe:
+			s.unsubscribe(message)
+		case messagePing:
+			if message.Location != nil {
+				err := rh.realtimeService.UpdateLocation(ctx, message.Location.Latitude, message.Location.Longitude)
+				if err != nil {
+					s.sendError(err.Error())
+					continue
+				}
+			}
+			s.send(serverMessage{Type: messagePong})
+		case messageAck:
+			if err := rh.realtimeService.Acknowledge(ctx, message.EventID); err != nil {
+				s.sendError(err.Error())
+			}
+		default:
+			s.sendError(errUnknownMessageType)
+		}
+	}
+}
+
+func (s *session) subscribe(message clientMessage) {
+	if message.SubscriptionID == "" {
+		s.sendError(errMissingSubscriptionID)
+		return
+	}
+
+	filter := reports.ReportEventFilter{IncidentTypes: message.IncidentTypes}
+	if message.Area != nil {
+		filter.BoundingBox = &geo.BoundingBox{
+			MinLatitude:  message.Area.MinLatitude,
+			MinLongitude: message.Area.MinLongitude,
+			MaxLatitude:  message.Area.MaxLatitude,
+			MaxLongitude: message.Area.MaxLongitude,
+		}
+		if !reports.IsValidBoundingBox(*filter.BoundingBox) {
+			s.sendError(reports.ErrInvalidBoundingBox.Error())
+			return
+		}
+	}
+
+	s.mu.Lock()
+	_, exists := s.subscriptions[message.SubscriptionID]
+	if !exists && len(s.subscriptions) >= maxSubscriptionsPerConn {
+		s.mu.Unlock()
+		s.sendError(errTooManySubscriptions)
+		return
+	}
+	s.subscriptions[message.SubscriptionID] = filter
+	missed := s.missed
+	s.mu.Unlock()
+
+	s.send(serverMessage{Type: messageSubscribed, SubscriptionID: message.SubscriptionID})
+
+	// events missed while disconnected are replayed to each new subscription
+	for _, event := range missed {
+		if filter.Matches(event) {
+			s.sendEvent(event, []string{message.SubscriptionID})
+		}
+	}
+}
+
+func (s *session) unsubscribe(message clientMessage) {
+	if message.SubscriptionID == "" {
+		s.sendError(errMissingSubscriptionID)
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.subscriptions, message.SubscriptionID)
+	s.mu.Unlock()
+
+	s.send(serverMessage{Type: messageUnsubscribed, SubscriptionID: message.SubscriptionID})
+}
+
+func (s *session) matchingSubscriptions(event domain.ReportEvent) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []string{}
+	for id, filter := range s.subscriptions {
+		if filter.Matches(event) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (s *session) sendEvent(event domain.ReportEvent, subscriptionIds []string) {
+	if len(subscriptionIds) == 0 {
+		return
+	}
+	report := reportsHandlers.ToReportDTO(event.Report, s.viewer)
+	s.send(serverMessage{
+		Type:            messageEvent,
+		SubscriptionIDs: subscriptionIds,
+		EventID:         event.ID,
+		Event:           event.Type,
+		Report:          &report,
+	})
+}
+
+func (s *session) sendError(message string) {
+	s.send(serverMessage{Type: messageError, Message: message})
+}
+
+func (s *session) send(message serverMessage) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := websocket.JSON.Send(s.conn, message); err != nil {
+		s.logger.Debug("unable to write to realtime session", zap.Error(err))
+	}
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/usecases/realtime"
+	"go.uber.org/zap"
+)
+
+type RealtimeHandler struct {
+	realtimeService realtime.RealtimeService
+	logger          *zap.Logger
+}
+
+func NewRealtimeHandler(realtimeService realtime.RealtimeService, logger *zap.Logger) (*RealtimeHandler, error) {
+	if realtimeService == (realtime.RealtimeService{}) {
+		return nil, errors.New("realtime service cannot be empty")
+	}
+
+	return &RealtimeHandler{realtimeService, logger}, nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	reportsHandlers "github.com/olad5/caution-companion/internal/handlers/reports"
+)
+
+const (
+	messageSubscribe    = "subscribe"
+	messageUnsubscribe  = "unsubscribe"
+	messagePing         = "ping"
+	messageAck          = "ack"
+	messageSubscribed   = "subscribed"
+	messageUnsubscribed = "unsubscribed"
+	messagePong         = "pong"
+	messageEvent        = "event"
+	messageError        = "error"
+	messageClose        = "close"
+)
+
+type area struct {
+	MinLatitude  float64 `json:"min_lat"`
+	MinLongitude float64 `json:"min_lng"`
+	MaxLatitude  float64 `json:"max_lat"`
+	MaxLongitude float64 `json:"max_lng"`
+}
+
+type location struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+}
+
+// clientMessage is every message a client can send, only the fields that
+// belong to Type are read.
+type clientMessage struct {
+	Type string `json:"type"`
+	// SubscriptionID names a subscription so it can be removed later
+	SubscriptionID string    `json:"subscription_id,omitempty"`
+	Area           *area     `json:"area,omitempty"`
+	IncidentTypes  []string  `json:"incident_types,omitempty"`
+	Location       *location `json:"location,omitempty"`
+	EventID        string    `json:"event_id,omitempty"`
+}
+
+type serverMessage struct {
+	Type           string `json:"type"`
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	// SubscriptionIDs lists every subscription an event matched
+	SubscriptionIDs []string                   `json:"subscription_ids,omitempty"`
+	EventID         string                     `json:"event_id,omitempty"`
+	Event           string                     `json:"event,omitempty"`
+	Report          *reportsHandlers.ReportDTO `json:"report,omitempty"`
+	Message         string                     `json:"message,omitempty"`
+}
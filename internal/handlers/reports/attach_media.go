@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (rh ReportsHandler) AttachMedia(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		MediaIds []string `json:"media_ids" validate:"required,min=1,max=10,dive,uuid"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := rh.userService.AttachMedia(ctx, id, parseMediaIds(request.MediaIds))
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, reports.ErrMediaNotFound),
+			errors.Is(err, reports.ErrTooManyMedia):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, reports.ErrMediaNotOwned),
+			errors.Is(err, reports.ErrNotReportOwner):
+			response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "media attached successfully",
+		ToReportDTO(report, ViewerFromContext(ctx)), rh.logger)
+}
+
+// parseMediaIds expects ids that have already passed uuid validation.
+func parseMediaIds(ids []string) []uuid.UUID {
+	result := []uuid.UUID{}
+	for _, id := range ids {
+		result = append(result, uuid.MustParse(id))
+	}
+	return result
+}
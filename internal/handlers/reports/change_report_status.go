@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (rh ReportsHandler) ChangeReportStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Status string `json:"status" validate:"required"`
+		Note   string `json:"note" validate:"omitempty,max=500"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedReport, err := rh.userService.ChangeReportStatus(ctx, id, request.Status, request.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, reports.ErrInvalidStatus):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, reports.ErrInvalidStatusTransition):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, reports.ErrStatusChangeForbidden):
+			response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, infra.ErrReportStatusConflict):
+			response.ErrorResponse(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "report status updated successfully", ToReportDTO(updatedReport, ViewerFromContext(ctx)), rh.logger)
+}
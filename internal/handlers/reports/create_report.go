@@ -4,6 +4,8 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
 	"github.com/olad5/caution-companion/internal/usecases/reports"
 	appErrors "github.com/olad5/caution-companion/pkg/errors"
 	response "github.com/olad5/caution-companion/pkg/utils"
@@ -17,18 +19,7 @@ func (rh ReportsHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type location struct {
-		Longitude string `json:"longitude" validate:"required,latitude"`
-		Latitude  string `json:"latitude" validate:"required,longitude"`
-	}
-
-	type requestDTO struct {
-		IncidentType string   `json:"incident_type" validate:"required"`
-		Location     location `json:"location" validate:"required"`
-		Description  string   `json:"description" validate:"required"`
-	}
-
-	request, err := response.Decode[requestDTO](r)
+	request, err := response.Decode[createReportRequestDTO](r)
 	if err != nil {
 		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
 		return
@@ -44,19 +35,88 @@ func (rh ReportsHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
 		ctx, request.IncidentType,
 		request.Location.Longitude,
 		request.Location.Latitude,
-		request.Description)
+		request.Description,
+		request.options())
 	if err != nil {
-		switch {
-
-		case errors.Is(err, reports.ErrInvalidIncidentType):
-			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		var duplicatesErr *reports.DuplicateReportsError
+		switch status := createReportErrorStatus(err); {
+		case errors.As(err, &duplicatesErr):
+			response.ErrorResponseWithData(w, err.Error(),
+				ToReportDTOs(duplicatesErr.Duplicates, ViewerFromContext(ctx)), status)
 			return
-		default:
+		case status == http.StatusInternalServerError:
 			response.InternalServerErrorResponse(w, err, rh.logger)
 			return
+		default:
+			response.ErrorResponse(w, err.Error(), status)
+			return
 		}
 	}
 
 	response.SuccessResponse(w, "report created successfully",
-		ToReportDTO(newReport), rh.logger)
+		ToReportDTO(newReport, ViewerFromContext(ctx)), rh.logger)
+}
+
+type locationDTO struct {
+	Longitude string `json:"longitude" validate:"required,longitude"`
+	Latitude  string `json:"latitude" validate:"required,latitude"`
+}
+
+type createReportRequestDTO struct {
+	IncidentType string      `json:"incident_type" validate:"required"`
+	Location     locationDTO `json:"location" validate:"required"`
+	Description  string      `json:"description" validate:"required"`
+	MediaIds     []string    `json:"media_ids" validate:"omitempty,max=10,dive,uuid"`
+	// Corroborates is the id of the report this one confirms
+	Corroborates    string `json:"corroborates" validate:"omitempty,uuid"`
+	CheckDuplicates bool   `json:"check_duplicates"`
+	Anonymous       bool   `json:"anonymous"`
+}
+
+// options expects a request that was already validated.
+func (request createReportRequestDTO) options() reports.CreateReportOptions {
+	return reports.CreateReportOptions{
+		MediaIds:        parseMediaIds(request.MediaIds),
+		Corroborates:    parseOptionalId(request.Corroborates),
+		CheckDuplicates: request.CheckDuplicates,
+		Anonymous:       request.Anonymous,
+	}
+}
+
+// createReportErrorStatus is the status code CreateReport errors are sent
+// with, unknown errors are internal server errors.
+func createReportErrorStatus(err error) int {
+	var duplicatesErr *reports.DuplicateReportsError
+	switch {
+	case errors.As(err, &duplicatesErr):
+		return http.StatusConflict
+	case errors.Is(err, infra.ErrReportNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, reports.ErrInvalidIncidentType),
+		errors.Is(err, reports.ErrInvalidLocation),
+		errors.Is(err, reports.ErrContentNotAllowed),
+		errors.Is(err, reports.ErrAnonymityNotAllowed),
+		errors.Is(err, reports.ErrInvalidOccurredAt),
+		errors.Is(err, reports.ErrOccurredAtExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, reports.ErrTooManyAnonymous):
+		return http.StatusTooManyRequests
+	case errors.Is(err, reports.ErrMediaNotFound),
+		errors.Is(err, reports.ErrTooManyMedia):
+		return http.StatusBadRequest
+	case errors.Is(err, reports.ErrMediaNotOwned),
+		errors.Is(err, reports.ErrUserSuspended):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseOptionalId expects ids that were already validated, empty ids are
+// uuid.Nil.
+func parseOptionalId(id string) uuid.UUID {
+	if id == "" {
+		return uuid.Nil
+	}
+	return uuid.MustParse(id)
 }
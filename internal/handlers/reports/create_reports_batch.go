@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+	"go.uber.org/zap"
+)
+
+// CreateReportsBatch responds with a result for each report of the batch in
+// the order they were sent, reports that are not valid fail on their own.
+func (rh ReportsHandler) CreateReportsBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Reports []json.RawMessage `json:"reports"`
+	}
+
+	type batchReportRequestDTO struct {
+		createReportRequestDTO
+		ClientID   string     `json:"client_id" validate:"required,uuid"`
+		OccurredAt *time.Time `json:"occurred_at" validate:"required"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+	switch {
+	case len(request.Reports) == 0:
+		response.ErrorResponse(w, reports.ErrEmptyBatch.Error(), http.StatusBadRequest)
+		return
+	case len(request.Reports) > reports.MaxBatchReports:
+		response.ErrorResponse(w, reports.ErrBatchTooLarge.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results := make([]BatchReportResultDTO, len(request.Reports))
+	batch := []reports.BatchReport{}
+	positions := []int{}
+	for i, raw := range request.Reports {
+		var item batchReportRequestDTO
+		if err := json.Unmarshal(raw, &item); err != nil {
+			results[i] = failedBatchReportDTO(item.ClientID, appErrors.ErrInvalidJson, http.StatusBadRequest)
+			continue
+		}
+		if err := utils.Check(item); err != nil {
+			results[i] = failedBatchReportDTO(item.ClientID, err.Error(), http.StatusBadRequest)
+			continue
+		}
+
+		options := item.options()
+		options.ClientID = uuid.MustParse(item.ClientID)
+		options.OccurredAt = *item.OccurredAt
+		batch = append(batch, reports.BatchReport{
+			IncidentType: item.IncidentType,
+			Longitude:    item.Location.Longitude,
+			Latitude:     item.Location.Latitude,
+			Description:  item.Description,
+			Options:      options,
+		})
+		positions = append(positions, i)
+	}
+
+	if len(batch) > 0 {
+		created, err := rh.userService.CreateReports(ctx, batch)
+		if err != nil {
+			switch {
+			case errors.Is(err, reports.ErrEmptyBatch),
+				errors.Is(err, reports.ErrBatchTooLarge):
+				response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+				return
+			default:
+				response.InternalServerErrorResponse(w, err, rh.logger)
+				return
+			}
+		}
+		for i, result := range created {
+			results[positions[i]] = rh.toBatchReportResultDTO(result, ViewerFromContext(ctx))
+		}
+	}
+
+	response.SuccessResponse(w, "reports processed", results, rh.logger)
+}
+
+func (rh ReportsHandler) toBatchReportResultDTO(result reports.BatchReportResult, viewer Viewer) BatchReportResultDTO {
+	clientId := result.ClientID.String()
+	if result.Err != nil {
+		status := createReportErrorStatus(result.Err)
+		if errors.Is(result.Err, reports.ErrClientIDRemoved) {
+			status = http.StatusGone
+		}
+		if status == http.StatusInternalServerError {
+			rh.logger.Error("[INTERNAL_SERVER_ERR]", zap.Error(result.Err))
+			return failedBatchReportDTO(clientId, appErrors.ErrSomethingWentWrong, status)
+		}
+		failed := failedBatchReportDTO(clientId, result.Err.Error(), status)
+		var duplicatesErr *reports.DuplicateReportsError
+		if errors.As(result.Err, &duplicatesErr) {
+			failed.Duplicates = ToReportDTOs(duplicatesErr.Duplicates, viewer)
+		}
+		return failed
+	}
+
+	report := ToReportDTO(result.Report, viewer)
+	status := BatchReportCreated
+	if result.Duplicate {
+		status = BatchReportDuplicate
+	}
+	return BatchReportResultDTO{ClientID: clientId, Status: status, Report: &report}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (rh ReportsHandler) DeleteReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	err = rh.userService.DeleteReport(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, reports.ErrNotReportOwner):
+			response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "report deleted successfully", nil, rh.logger)
+}
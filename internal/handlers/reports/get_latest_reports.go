@@ -1,26 +1,99 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
 	apiUtils "github.com/olad5/caution-companion/pkg/utils"
 )
 
 func (rh ReportsHandler) GetLatestReports(w http.ResponseWriter, r *http.Request) {
+	if acceptsGeoJSON(r) {
+		rh.GetReportsGeoJSON(w, r)
+		return
+	}
+
 	ctx := r.Context()
 	pageInfo, err := apiUtils.ParseRequest(r)
 	if err != nil {
 		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := parseReportFeedQuery(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	reports, err := rh.userService.GetLatestReports(ctx, pageInfo.Number, pageInfo.RowsPerPage)
+	feed, err := rh.userService.GetReportFeed(
+		ctx,
+		query,
+		r.URL.Query().Get("sort"),
+		r.URL.Query().Get("cursor"),
+		pageInfo.RowsPerPage)
 	if err != nil {
-		switch {
-		default:
-			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
-			return
+		rh.feedErrorResponse(w, err)
+		return
+	}
+
+	votes, err := rh.userService.GetUserVotes(ctx, feed.Reports)
+	if err != nil {
+		apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	result := ToReportFeedDTO(feed, ViewerFromContext(ctx))
+	applyUserVotes(result.Items, feed.Reports, votes)
+	apiUtils.SuccessResponse(w, "latest reports retrieved successfully", result, rh.logger)
+}
+
+func (rh ReportsHandler) feedErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, reports.ErrInvalidSort),
+		errors.Is(err, reports.ErrInvalidCursor),
+		errors.Is(err, reports.ErrInvalidLimit),
+		errors.Is(err, reports.ErrInvalidExportLimit),
+		errors.Is(err, reports.ErrInvalidStatus):
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, infra.ErrUserNotFound):
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, reports.ErrReportHistoryPrivate):
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusForbidden)
+	default:
+		apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+	}
+}
+
+func parseReportFeedQuery(r *http.Request) (infra.ReportFeedQuery, error) {
+	values := r.URL.Query()
+	query := infra.ReportFeedQuery{
+		IncidentTypes: parseListQuery(r, "incident_type"),
+		Status:        values.Get("status"),
+		StateCode:     strings.ToUpper(values.Get("state")),
+		LGACode:       strings.ToUpper(values.Get("lga")),
+	}
+
+	if owner := values.Get("owner_id"); owner != "" {
+		ownerId, err := uuid.Parse(owner)
+		if err != nil {
+			return infra.ReportFeedQuery{}, errors.New("owner_id must be a valid id")
 		}
+		query.OwnerID = ownerId
 	}
 
-	apiUtils.SuccessResponse(w, "latest reports retrieved successfully", ToReportsPagedDTO(reports, pageInfo.Number), rh.logger)
+	var err error
+	query.CreatedAfter, err = parseTimeQuery(r, "created_after")
+	if err != nil {
+		return infra.ReportFeedQuery{}, err
+	}
+	query.CreatedBefore, err = parseTimeQuery(r, "created_before")
+	if err != nil {
+		return infra.ReportFeedQuery{}, err
+	}
+	return query, nil
 }
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+func (rh ReportsHandler) GetMapView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var box geo.BoundingBox
+	bounds := []struct {
+		key   string
+		value *float64
+	}{
+		{"min_lat", &box.MinLatitude},
+		{"min_lng", &box.MinLongitude},
+		{"max_lat", &box.MaxLatitude},
+		{"max_lng", &box.MaxLongitude},
+	}
+	for _, bound := range bounds {
+		value, err := parseFloatQuery(r, bound.key, true, 0)
+		if err != nil {
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*bound.value = value
+	}
+
+	zoom, err := strconv.Atoi(r.URL.Query().Get("zoom"))
+	if err != nil {
+		apiUtils.ErrorResponse(w, "zoom must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	mapView, err := rh.userService.GetMapView(ctx, box, zoom)
+	if err != nil {
+		switch {
+		case errors.Is(err, reports.ErrInvalidBoundingBox):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, reports.ErrInvalidZoom):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	apiUtils.SuccessResponse(w, "map view retrieved successfully", ToMapViewDTO(mapView, ViewerFromContext(ctx)), rh.logger)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+)
+
+const defaultNearbyRadiusInMeters = 2000
+
+func (rh ReportsHandler) GetNearbyReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pageInfo, err := apiUtils.ParseRequest(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	latitude, err := parseFloatQuery(r, "lat", true, 0)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	longitude, err := parseFloatQuery(r, "lng", true, 0)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	radius, err := parseFloatQuery(r, "radius", false, defaultNearbyRadiusInMeters)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	createdAfter, err := parseTimeQuery(r, "created_after")
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	createdBefore, err := parseTimeQuery(r, "created_before")
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	nearbyReports, err := rh.userService.GetNearbyReports(
+		ctx,
+		latitude,
+		longitude,
+		radius,
+		r.URL.Query().Get("incident_type"),
+		createdAfter,
+		createdBefore,
+		pageInfo.Number,
+		pageInfo.RowsPerPage)
+	if err != nil {
+		switch {
+		case errors.Is(err, reports.ErrInvalidLocation):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, reports.ErrInvalidRadius):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	plainReports := []domain.Report{}
+	for _, report := range nearbyReports {
+		plainReports = append(plainReports, report.Report)
+	}
+	votes, err := rh.userService.GetUserVotes(ctx, plainReports)
+	if err != nil {
+		apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	result := ToNearbyReportsPagedDTO(nearbyReports, pageInfo.Number, ViewerFromContext(ctx))
+	applyUserVotes(result.Items, plainReports, votes)
+	apiUtils.SuccessResponse(w, "nearby reports retrieved successfully", result, rh.logger)
+}
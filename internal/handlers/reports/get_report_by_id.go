@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
 	"github.com/olad5/caution-companion/internal/infra"
 	appErrors "github.com/olad5/caution-companion/pkg/errors"
 	response "github.com/olad5/caution-companion/pkg/utils"
@@ -16,6 +17,7 @@ func (rh ReportsHandler) GetReportByReportId(w http.ResponseWriter, r *http.Requ
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
 	}
 
 	report, err := rh.userService.GetReportByReportId(ctx, id)
@@ -30,5 +32,23 @@ func (rh ReportsHandler) GetReportByReportId(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	response.SuccessResponse(w, "report retrieved successfully", ToReportDTO(report), rh.logger)
+	history, err := rh.userService.GetReportStatusHistory(ctx, id)
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	votes, err := rh.userService.GetUserVotes(ctx, []domain.Report{report})
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	viewer := ViewerFromContext(ctx)
+	reportDTO := ToReportDTO(report, viewer)
+	reportDTO.StatusHistory = ToReportStatusChangeDTOs(history, viewer)
+	if vote, ok := votes[report.ID]; ok {
+		reportDTO.MyVote = &vote
+	}
+	response.SuccessResponse(w, "report retrieved successfully", reportDTO, rh.logger)
 }
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (rh ReportsHandler) GetReportStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	box, err := parseBoundingBoxQuery(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := infra.ReportStatsQuery{
+		Box:           box,
+		IncidentTypes: parseListQuery(r, "incident_type"),
+	}
+	query.From, err = parseTimeQuery(r, "from")
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query.To, err = parseTimeQuery(r, "to")
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := rh.userService.GetReportStats(ctx, query, r.URL.Query().Get("time_zone"))
+	if err != nil {
+		switch {
+		case errors.Is(err, reports.ErrInvalidTimeRange),
+			errors.Is(err, reports.ErrInvalidTimeZone),
+			errors.Is(err, reports.ErrInvalidBoundingBox):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	apiUtils.SuccessResponse(w, "report stats retrieved successfully", ToReportStatsDTO(stats), rh.logger)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const geoJSONContentType = "application/geo+json"
+
+// GetReportsGeoJSON lists the feed as a GeoJSON FeatureCollection of at
+// most limit features, reports.MaxExportLimit by default. When more reports
+// match, the collection ends with a next_cursor member to carry on from. It
+// takes the filters of the feed and is written out a page at a time, so a
+// failure after the first page can only be told by the document being
+// incomplete.
+func (rh ReportsHandler) GetReportsGeoJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query, err := parseReportFeedQuery(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit := reports.MaxExportLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			apiUtils.ErrorResponse(w, reports.ErrInvalidExportLimit.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	viewer := ViewerFromContext(ctx)
+	controller := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	started, features := false, 0
+	nextCursor, err := rh.userService.EachReportFeedPage(
+		ctx,
+		query,
+		r.URL.Query().Get("sort"),
+		r.URL.Query().Get("cursor"),
+		limit,
+		func(page []domain.Report) error {
+			votes, err := rh.userService.GetUserVotes(ctx, page)
+			if err != nil {
+				return err
+			}
+			if !started {
+				started = true
+				w.Header().Set("Content-Type", geoJSONContentType)
+				w.WriteHeader(http.StatusOK)
+				if _, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`); err != nil {
+					return err
+				}
+			}
+
+			items := ToReportsPagedDTO(page, 0, viewer).Items
+			applyUserVotes(items, page, votes)
+			for i, report := range page {
+				if features > 0 {
+					if _, err := io.WriteString(w, ","); err != nil {
+						return err
+					}
+				}
+				if err := encoder.Encode(ToGeoJSONFeatureDTO(report, items[i])); err != nil {
+					return err
+				}
+				features++
+			}
+			if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return err
+			}
+			return nil
+		})
+	if err != nil {
+		if !started {
+			rh.feedErrorResponse(w, err)
+			return
+		}
+		if !errors.Is(err, context.Canceled) {
+			rh.logger.Error("unable to finish streaming reports as GeoJSON", zap.Error(err))
+		}
+		return
+	}
+
+	end := "]}\n"
+	if nextCursor != "" {
+		encodedCursor, err := json.Marshal(nextCursor)
+		if err != nil {
+			rh.logger.Error("unable to finish streaming reports as GeoJSON", zap.Error(err))
+			return
+		}
+		end = `],"next_cursor":` + string(encodedCursor) + "}\n"
+	}
+	if _, err := io.WriteString(w, end); err != nil {
+		rh.logger.Error("unable to finish streaming reports as GeoJSON", zap.Error(err))
+	}
+}
+
+// acceptsGeoJSON lets listings be asked for as GeoJSON through the Accept
+// header instead of the .geojson route.
+func acceptsGeoJSON(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == geoJSONContentType {
+			return true
+		}
+	}
+	return false
+}
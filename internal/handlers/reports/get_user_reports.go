@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (rh ReportsHandler) GetMyReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pageInfo, err := apiUtils.ParseRequest(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userReports, total, err := rh.userService.GetMyReports(
+		ctx, r.URL.Query().Get("status"), pageInfo.Number, pageInfo.RowsPerPage)
+	rh.writeUserReports(w, r, userReports, total, pageInfo.Number, err)
+}
+
+func (rh ReportsHandler) GetReportsByUserName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pageInfo, err := apiUtils.ParseRequest(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userReports, total, err := rh.userService.GetReportsByUserName(
+		ctx, chi.URLParam(r, "user_name"), r.URL.Query().Get("status"),
+		pageInfo.Number, pageInfo.RowsPerPage)
+	rh.writeUserReports(w, r, userReports, total, pageInfo.Number, err)
+}
+
+func (rh ReportsHandler) writeUserReports(
+	w http.ResponseWriter, r *http.Request, userReports []domain.Report, total, page int, err error,
+) {
+	if err != nil {
+		switch {
+		case errors.Is(err, reports.ErrInvalidStatus):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, infra.ErrUserNotFound):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, reports.ErrReportHistoryPrivate):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, reports.ErrInvalidToken):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusUnauthorized)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	votes, err := rh.userService.GetUserVotes(r.Context(), userReports)
+	if err != nil {
+		apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	result := ToReportsPagedDTO(userReports, page, ViewerFromContext(r.Context()))
+	applyUserVotes(result.Items, userReports, votes)
+	result.Total = &total
+	apiUtils.SuccessResponse(w, "reports retrieved successfully", result, rh.logger)
+}
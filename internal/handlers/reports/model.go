@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
 )
 
 type location struct {
@@ -12,38 +16,264 @@ type location struct {
 }
 
 type ReportDTO struct {
-	ID           string     `json:"id"`
-	IncidentType string     `json:"incident_type"`
-	Location     location   `json:"location"`
-	Description  string     `json:"description"`
-	CreatedAt    *time.Time `json:"created_at"`
-	UpdatedAt    *time.Time `json:"updated_at"`
+	ID string `json:"id"`
+	// OwnerID is only shown to the owner and moderators, it is null for
+	// anonymous reports
+	OwnerID          *string                 `json:"owner_id"`
+	IsAnonymous      bool                    `json:"is_anonymous"`
+	IncidentType     string                  `json:"incident_type"`
+	Location         location                `json:"location"`
+	State            *string                 `json:"state"`
+	LGA              *string                 `json:"lga"`
+	Description      string                  `json:"description"`
+	Status           string                  `json:"status"`
+	StatusHistory    []ReportStatusChangeDTO `json:"status_history,omitempty"`
+	Media            []ReportMediaDTO        `json:"media"`
+	Confirmations    int                     `json:"confirmations"`
+	Disputes         int                     `json:"disputes"`
+	CredibilityScore float64                 `json:"credibility_score"`
+	DuplicateOf      *string                 `json:"duplicate_of"`
+	Corroborations   int                     `json:"corroboration_count"`
+	MyVote           *string                 `json:"my_vote"`
+	DistanceInMeters *float64                `json:"distance_in_meters,omitempty"`
+	SearchRank       *float64                `json:"search_rank,omitempty"`
+	Snippet          string                  `json:"snippet,omitempty"`
+	OccurredAt       *time.Time              `json:"occurred_at,omitempty"`
+	CreatedAt        *time.Time              `json:"created_at"`
+	UpdatedAt        *time.Time              `json:"updated_at"`
+	DeletedAt        *time.Time              `json:"deleted_at,omitempty"`
+	HiddenAt         *time.Time              `json:"hidden_at,omitempty"`
 }
 
-func ToReportDTO(report domain.Report) ReportDTO {
-	return ReportDTO{
+type ReportMediaDTO struct {
+	FileID   string `json:"file_id"`
+	Url      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Position int    `json:"position"`
+}
+
+type ReportStatusChangeDTO struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	// ChangedBy is only shown to that user and moderators
+	ChangedBy *string    `json:"changed_by"`
+	Note      string     `json:"note"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// Viewer is the user reports are shown to.
+type Viewer struct {
+	UserID      uuid.UUID
+	IsModerator bool
+}
+
+func ViewerFromContext(ctx context.Context) Viewer {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return Viewer{}
+	}
+	return Viewer{
+		UserID:      jwtClaims.ID,
+		IsModerator: jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin),
+	}
+}
+
+func (v Viewer) canSeeOwner(report domain.Report) bool {
+	return v.canSeeUser(report.OwnerID)
+}
+
+// canSeeUser is true when the viewer is the user or a moderator, user ids
+// are not shown to anyone else.
+func (v Viewer) canSeeUser(userId uuid.UUID) bool {
+	return v.IsModerator || (v.UserID != uuid.Nil && v.UserID == userId)
+}
+
+func ToReportDTO(report domain.Report, viewer Viewer) ReportDTO {
+	result := ReportDTO{
 		ID:           report.ID.String(),
 		IncidentType: report.IncidentType,
 		Location: location{
 			Longitude: report.Longitude,
 			Latitude:  report.Latitude,
 		},
-		Description: report.Description,
-		CreatedAt:   &report.CreatedAt,
-		UpdatedAt:   &report.UpdatedAt,
+		Description:      report.Description,
+		Status:           report.Status,
+		Media:            ToReportMediaDTOs(report.Media),
+		Confirmations:    report.ConfirmationCount,
+		Disputes:         report.DisputeCount,
+		CredibilityScore: report.CredibilityScore,
+		IsAnonymous:      report.IsAnonymous,
+		Corroborations:   report.CorroborationCount,
+		CreatedAt:        &report.CreatedAt,
+		UpdatedAt:        &report.UpdatedAt,
+	}
+	if !report.IsAnonymous && viewer.canSeeOwner(report) {
+		ownerId := report.OwnerID.String()
+		result.OwnerID = &ownerId
+	}
+	if report.StateCode != "" {
+		result.State = &report.StateCode
+	}
+	if report.LGACode != "" {
+		result.LGA = &report.LGACode
+	}
+	if report.IsCorroboration() {
+		duplicateOf := report.DuplicateOf.String()
+		result.DuplicateOf = &duplicateOf
+	}
+	if report.IsDeleted() {
+		result.DeletedAt = &report.DeletedAt
+	}
+	if report.IsHidden() {
+		result.HiddenAt = &report.HiddenAt
+	}
+	if !report.OccurredAt.IsZero() {
+		result.OccurredAt = &report.OccurredAt
+	}
+	return result
+}
+
+type ReportLinkDTO struct {
+	ReportID          string  `json:"report_id"`
+	PrimaryID         *string `json:"primary_id"`
+	PreviousPrimaryID *string `json:"previous_primary_id"`
+	Action            string  `json:"action"`
+	// ActorID is only shown to that user and moderators
+	ActorID   *string    `json:"actor_id"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func ToReportLinkDTOs(links []domain.ReportLink, viewer Viewer) []ReportLinkDTO {
+	items := []ReportLinkDTO{}
+	for _, link := range links {
+		item := ReportLinkDTO{
+			ReportID:  link.ReportID.String(),
+			Action:    link.Action,
+			CreatedAt: &link.CreatedAt,
+		}
+		if link.ActorID != uuid.Nil && viewer.canSeeUser(link.ActorID) {
+			actorId := link.ActorID.String()
+			item.ActorID = &actorId
+		}
+		if link.PrimaryID != uuid.Nil {
+			primaryId := link.PrimaryID.String()
+			item.PrimaryID = &primaryId
+		}
+		if link.PreviousPrimaryID != uuid.Nil {
+			previousPrimaryId := link.PreviousPrimaryID.String()
+			item.PreviousPrimaryID = &previousPrimaryId
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func ToReportDTOs(reports []domain.Report, viewer Viewer) []ReportDTO {
+	items := []ReportDTO{}
+	for _, report := range reports {
+		items = append(items, ToReportDTO(report, viewer))
+	}
+	return items
+}
+
+// applyUserVotes expects items to be in the same order as the reports they
+// were built from.
+func applyUserVotes(items []ReportDTO, reports []domain.Report, votes map[uuid.UUID]string) {
+	for i, report := range reports {
+		if vote, ok := votes[report.ID]; ok {
+			items[i].MyVote = &vote
+		}
 	}
 }
 
+func ToReportMediaDTOs(media []domain.ReportMedia) []ReportMediaDTO {
+	items := []ReportMediaDTO{}
+	for _, element := range media {
+		items = append(items, ReportMediaDTO{
+			FileID:   element.FileID.String(),
+			Url:      element.Url,
+			MimeType: element.MimeType,
+			Position: element.Position,
+		})
+	}
+	return items
+}
+
+func ToReportStatusChangeDTOs(changes []domain.ReportStatusChange, viewer Viewer) []ReportStatusChangeDTO {
+	items := []ReportStatusChangeDTO{}
+	for _, change := range changes {
+		item := ReportStatusChangeDTO{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Note:       change.Note,
+			CreatedAt:  &change.CreatedAt,
+		}
+		if change.ChangedBy != uuid.Nil && viewer.canSeeUser(change.ChangedBy) {
+			changedBy := change.ChangedBy.String()
+			item.ChangedBy = &changedBy
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// ReportsPagedDTO is shared by page based listings and the cursor based
+// feed, Page is left out of the feed and NextCursor out of the rest.
 type ReportsPagedDTO struct {
-	Rows  int         `json:"rows"`
-	Page  int         `json:"page"`
-	Items []ReportDTO `json:"items"`
+	Rows       int         `json:"rows"`
+	Page       int         `json:"page,omitempty"`
+	Total      *int        `json:"total,omitempty"`
+	NextCursor *string     `json:"next_cursor,omitempty"`
+	HasMore    *bool       `json:"has_more,omitempty"`
+	Items      []ReportDTO `json:"items"`
+}
+
+func ToReportsPagedDTO(reports []domain.Report, page int, viewer Viewer) ReportsPagedDTO {
+	items := []ReportDTO{}
+	for _, report := range reports {
+		items = append(items, ToReportDTO(report, viewer))
+	}
+	return ReportsPagedDTO{
+		Page:  page,
+		Rows:  len(items),
+		Items: items,
+	}
+}
+
+func ToSearchResultsPagedDTO(results []domain.ReportSearchResult, page int, viewer Viewer) ReportsPagedDTO {
+	items := []ReportDTO{}
+	for _, result := range results {
+		item := ToReportDTO(result.Report, viewer)
+		rank := result.Rank
+		item.SearchRank = &rank
+		item.Snippet = result.Snippet
+		items = append(items, item)
+	}
+	return ReportsPagedDTO{
+		Page:  page,
+		Rows:  len(items),
+		Items: items,
+	}
+}
+
+func ToReportFeedDTO(feed reports.ReportFeedPage, viewer Viewer) ReportsPagedDTO {
+	result := ToReportsPagedDTO(feed.Reports, 0, viewer)
+	hasMore := feed.NextCursor != ""
+	result.Total = &feed.Total
+	result.HasMore = &hasMore
+	if hasMore {
+		result.NextCursor = &feed.NextCursor
+	}
+	return result
 }
 
-func ToReportsPagedDTO(reports []domain.Report, page int) ReportsPagedDTO {
+func ToNearbyReportsPagedDTO(reports []domain.NearbyReport, page int, viewer Viewer) ReportsPagedDTO {
 	items := []ReportDTO{}
 	for _, report := range reports {
-		items = append(items, ToReportDTO(report))
+		item := ToReportDTO(report.Report, viewer)
+		distance := report.DistanceInMeters
+		item.DistanceInMeters = &distance
+		items = append(items, item)
 	}
 	return ReportsPagedDTO{
 		Page:  page,
@@ -51,3 +281,175 @@ func ToReportsPagedDTO(reports []domain.Report, page int) ReportsPagedDTO {
 		Items: items,
 	}
 }
+
+type coordinates struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+type ReportClusterDTO struct {
+	Centroid      coordinates    `json:"centroid"`
+	Count         int            `json:"count"`
+	IncidentTypes map[string]int `json:"incident_types"`
+}
+
+type MapViewDTO struct {
+	Mode     string             `json:"mode"`
+	Reports  []ReportDTO        `json:"reports"`
+	Clusters []ReportClusterDTO `json:"clusters"`
+}
+
+func ToMapViewDTO(mapView domain.MapView, viewer Viewer) MapViewDTO {
+	result := MapViewDTO{
+		Mode:     "reports",
+		Reports:  []ReportDTO{},
+		Clusters: []ReportClusterDTO{},
+	}
+	if mapView.IsClustered {
+		result.Mode = "clusters"
+	}
+	for _, report := range mapView.Reports {
+		result.Reports = append(result.Reports, ToReportDTO(report, viewer))
+	}
+	for _, cluster := range mapView.Clusters {
+		result.Clusters = append(result.Clusters, ReportClusterDTO{
+			Centroid: coordinates{
+				Longitude: cluster.Longitude,
+				Latitude:  cluster.Latitude,
+			},
+			Count:         cluster.Count,
+			IncidentTypes: cluster.IncidentTypes,
+		})
+	}
+	return result
+}
+
+type IncidentTypeCountDTO struct {
+	IncidentType  string `json:"incident_type"`
+	Count         int    `json:"count"`
+	PreviousCount int    `json:"previous_count"`
+}
+
+type TimeBucketCountDTO struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type HourOfDayCountDTO struct {
+	Hour  int `json:"hour"`
+	Count int `json:"count"`
+}
+
+type CellCountDTO struct {
+	Center coordinates `json:"center"`
+	Count  int         `json:"count"`
+}
+
+type ReportStatsDTO struct {
+	From              time.Time              `json:"from"`
+	To                time.Time              `json:"to"`
+	PreviousFrom      time.Time              `json:"previous_from"`
+	TimeZone          string                 `json:"time_zone"`
+	Total             int                    `json:"total"`
+	PreviousTotal     int                    `json:"previous_total"`
+	CellSizeInDegrees float64                `json:"cell_size_in_degrees"`
+	ByIncidentType    []IncidentTypeCountDTO `json:"by_incident_type"`
+	ByDay             []TimeBucketCountDTO   `json:"by_day"`
+	ByWeek            []TimeBucketCountDTO   `json:"by_week"`
+	ByHourOfDay       []HourOfDayCountDTO    `json:"by_hour_of_day"`
+	ByCell            []CellCountDTO         `json:"by_cell"`
+}
+
+func ToReportStatsDTO(stats domain.ReportStats) ReportStatsDTO {
+	result := ReportStatsDTO{
+		From:              stats.From,
+		To:                stats.To,
+		PreviousFrom:      stats.PreviousFrom,
+		TimeZone:          stats.TimeZone,
+		Total:             stats.Total,
+		PreviousTotal:     stats.PreviousTotal,
+		CellSizeInDegrees: stats.CellSizeInDegrees,
+		ByIncidentType:    []IncidentTypeCountDTO{},
+		ByDay:             toTimeBucketCountDTOs(stats.ByDay),
+		ByWeek:            toTimeBucketCountDTOs(stats.ByWeek),
+		ByHourOfDay:       []HourOfDayCountDTO{},
+		ByCell:            []CellCountDTO{},
+	}
+	for _, count := range stats.ByIncidentType {
+		result.ByIncidentType = append(result.ByIncidentType, IncidentTypeCountDTO(count))
+	}
+	for hour, count := range stats.ByHourOfDay {
+		result.ByHourOfDay = append(result.ByHourOfDay, HourOfDayCountDTO{Hour: hour, Count: count})
+	}
+	for _, cell := range stats.ByCell {
+		result.ByCell = append(result.ByCell, CellCountDTO{
+			Center: coordinates{
+				Longitude: cell.Longitude,
+				Latitude:  cell.Latitude,
+			},
+			Count: cell.Count,
+		})
+	}
+	return result
+}
+
+func toTimeBucketCountDTOs(buckets []domain.TimeBucketCount) []TimeBucketCountDTO {
+	result := []TimeBucketCountDTO{}
+	for _, bucket := range buckets {
+		result = append(result, TimeBucketCountDTO(bucket))
+	}
+	return result
+}
+
+type GeoJSONPointDTO struct {
+	Type string `json:"type"`
+	// Coordinates are longitude first, as GeoJSON requires
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type GeoJSONFeatureDTO struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   GeoJSONPointDTO `json:"geometry"`
+	Properties ReportDTO       `json:"properties"`
+}
+
+func ToGeoJSONFeatureDTO(report domain.Report, properties ReportDTO) GeoJSONFeatureDTO {
+	return GeoJSONFeatureDTO{
+		Type: "Feature",
+		ID:   report.ID.String(),
+		Geometry: GeoJSONPointDTO{
+			Type:        "Point",
+			Coordinates: [2]float64{report.Lng, report.Lat},
+		},
+		Properties: properties,
+	}
+}
+
+const (
+	BatchReportCreated   = "created"
+	BatchReportDuplicate = "duplicate"
+	BatchReportFailed    = "failed"
+)
+
+type BatchReportResultDTO struct {
+	ClientID string `json:"client_id"`
+	// Status is created, duplicate or failed, duplicate reports were made by
+	// an earlier sync and are returned as they are now
+	Status string     `json:"status"`
+	Report *ReportDTO `json:"report,omitempty"`
+	Error  string     `json:"error,omitempty"`
+	// Code is the status code the report would have failed with if it was
+	// sent on its own
+	Code       int         `json:"code,omitempty"`
+	Duplicates []ReportDTO `json:"duplicates,omitempty"`
+}
+
+func failedBatchReportDTO(clientId, message string, code int) BatchReportResultDTO {
+	return BatchReportResultDTO{
+		ClientID: clientId,
+		Status:   BatchReportFailed,
+		Error:    message,
+		Code:     code,
+	}
+}
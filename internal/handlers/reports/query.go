@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+func parseFloatQuery(r *http.Request, key string, required bool, fallback float64) (float64, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		if required {
+			return 0, fmt.Errorf("%s must have a value", key)
+		}
+		return fallback, nil
+	}
+
+	result, err := strconv.ParseFloat(value, 64)
+	// ParseFloat accepts NaN and Inf, which compare false against any bound
+	if err != nil || math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("%s must be a number", key)
+	}
+	return result, nil
+}
+
+// parseListQuery accepts key either repeated or as a comma separated list.
+func parseListQuery(r *http.Request, key string) []string {
+	result := []string{}
+	for _, value := range r.URL.Query()[key] {
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				result = append(result, element)
+			}
+		}
+	}
+	return result
+}
+
+// parseBoundingBoxQuery returns nil when none of the bounds are set, and an
+// error when only some of them are.
+func parseBoundingBoxQuery(r *http.Request) (*geo.BoundingBox, error) {
+	var box geo.BoundingBox
+	bounds := []struct {
+		key   string
+		value *float64
+	}{
+		{"min_lat", &box.MinLatitude},
+		{"min_lng", &box.MinLongitude},
+		{"max_lat", &box.MaxLatitude},
+		{"max_lng", &box.MaxLongitude},
+	}
+
+	set := 0
+	for _, bound := range bounds {
+		if r.URL.Query().Get(bound.key) != "" {
+			set++
+		}
+	}
+	if set == 0 {
+		return nil, nil
+	}
+
+	for _, bound := range bounds {
+		value, err := parseFloatQuery(r, bound.key, true, 0)
+		if err != nil {
+			return nil, err
+		}
+		*bound.value = value
+	}
+	return &box, nil
+}
+
+func parseTimeQuery(r *http.Request, key string) (time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp", key)
+	}
+	return result, nil
+}
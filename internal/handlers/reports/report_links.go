@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (rh ReportsHandler) MergeReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Into string `json:"into" validate:"required,uuid"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	primary, err := rh.userService.MergeReport(ctx, id, uuid.MustParse(request.Into))
+	if err != nil {
+		rh.reportLinkErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "report merged successfully", ToReportDTO(primary, ViewerFromContext(ctx)), rh.logger)
+}
+
+func (rh ReportsHandler) SplitReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	report, err := rh.userService.SplitReport(ctx, id)
+	if err != nil {
+		rh.reportLinkErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "report split successfully", ToReportDTO(report, ViewerFromContext(ctx)), rh.logger)
+}
+
+func (rh ReportsHandler) GetReportLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	links, err := rh.userService.GetReportLinks(ctx, id)
+	if err != nil {
+		rh.reportLinkErrorResponse(w, err)
+		return
+	}
+
+	response.SuccessResponse(w, "report links retrieved successfully", ToReportLinkDTOs(links, ViewerFromContext(ctx)), rh.logger)
+}
+
+func (rh ReportsHandler) GetCorroborations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	corroborations, err := rh.userService.GetCorroborations(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "corroborations retrieved successfully", ToReportDTOs(corroborations, ViewerFromContext(ctx)), rh.logger)
+}
+
+func (rh ReportsHandler) reportLinkErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, infra.ErrReportNotFound):
+		response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, reports.ErrModeratorsOnly):
+		response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, reports.ErrMergeIntoItself),
+		errors.Is(err, reports.ErrMergeIntoCorroboration),
+		errors.Is(err, reports.ErrNotCorroboration):
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+	default:
+		response.InternalServerErrorResponse(w, err, rh.logger)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+)
+
+func (rh ReportsHandler) SearchReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pageInfo, err := apiUtils.ParseRequest(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	createdAfter, err := parseTimeQuery(r, "created_after")
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	createdBefore, err := parseTimeQuery(r, "created_before")
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results, err := rh.userService.SearchReports(
+		ctx,
+		r.URL.Query().Get("q"),
+		r.URL.Query().Get("incident_type"),
+		createdAfter,
+		createdBefore,
+		pageInfo.Number,
+		pageInfo.RowsPerPage)
+	if err != nil {
+		switch {
+		case errors.Is(err, reports.ErrInvalidSearchQuery):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	plainReports := []domain.Report{}
+	for _, result := range results {
+		plainReports = append(plainReports, result.Report)
+	}
+	votes, err := rh.userService.GetUserVotes(ctx, plainReports)
+	if err != nil {
+		apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	result := ToSearchResultsPagedDTO(results, pageInfo.Number, ViewerFromContext(ctx))
+	applyUserVotes(result.Items, plainReports, votes)
+	apiUtils.SuccessResponse(w, "reports retrieved successfully", result, rh.logger)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	apiUtils "github.com/olad5/caution-companion/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+func (rh ReportsHandler) StreamReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	box, err := parseBoundingBoxQuery(r)
+	if err != nil {
+		apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter := reports.ReportEventFilter{
+		IncidentTypes: parseListQuery(r, "incident_type"),
+		BoundingBox:   box,
+	}
+
+	subscription, missed, err := rh.userService.SubscribeToReportEvents(
+		filter, r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, reports.ErrInvalidBoundingBox):
+			apiUtils.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			apiUtils.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+	defer subscription.Close()
+
+	viewer := ViewerFromContext(ctx)
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range missed {
+		if err := writeReportEvent(w, event, viewer); err != nil {
+			return
+		}
+	}
+	if err := controller.Flush(); err != nil {
+		rh.logger.Error("streaming is not supported by the response writer", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := writeReportEvent(w, event, viewer); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeReportEvent(w http.ResponseWriter, event domain.ReportEvent, viewer Viewer) error {
+	data, err := json.Marshal(ToReportDTO(event.Report, viewer))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (rh ReportsHandler) UpdateReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type location struct {
+		Longitude string `json:"longitude" validate:"required,longitude"`
+		Latitude  string `json:"latitude" validate:"required,latitude"`
+	}
+
+	type requestDTO struct {
+		IncidentType string   `json:"incident_type" validate:"required"`
+		Location     location `json:"location" validate:"required"`
+		Description  string   `json:"description" validate:"required"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedReport, err := rh.userService.UpdateReport(
+		ctx, id, request.IncidentType,
+		request.Location.Longitude,
+		request.Location.Latitude,
+		request.Description)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, reports.ErrNotReportOwner):
+			response.ErrorResponse(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, reports.ErrInvalidIncidentType),
+			errors.Is(err, reports.ErrInvalidLocation),
+			errors.Is(err, reports.ErrContentNotAllowed),
+			errors.Is(err, reports.ErrAnonymityNotAllowed):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "report updated successfully",
+		ToReportDTO(updatedReport, ViewerFromContext(ctx)), rh.logger)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+type ReportVotesDTO struct {
+	Confirmations    int     `json:"confirmations"`
+	Disputes         int     `json:"disputes"`
+	CredibilityScore float64 `json:"credibility_score"`
+	MyVote           *string `json:"my_vote"`
+}
+
+func toReportVotesDTO(report domain.Report, myVote string) ReportVotesDTO {
+	result := ReportVotesDTO{
+		Confirmations:    report.ConfirmationCount,
+		Disputes:         report.DisputeCount,
+		CredibilityScore: report.CredibilityScore,
+	}
+	if myVote != "" {
+		result.MyVote = &myVote
+	}
+	return result
+}
+
+func (rh ReportsHandler) VoteOnReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		Vote string `json:"vote" validate:"required"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := rh.userService.VoteOnReport(ctx, id, request.Vote)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, reports.ErrInvalidVote):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, reports.ErrCannotVoteOnOwnReport):
+			response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		case errors.Is(err, infra.ErrReportVoteExists):
+			response.ErrorResponse(w, err.Error(), http.StatusConflict)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "vote recorded successfully", toReportVotesDTO(report, request.Vote), rh.logger)
+}
+
+func (rh ReportsHandler) RetractVote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	report, err := rh.userService.RetractVote(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, infra.ErrReportVoteNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "vote retracted successfully", toReportVotesDTO(report, ""), rh.logger)
+}
+
+func (rh ReportsHandler) GetReportVotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidID, http.StatusBadRequest)
+		return
+	}
+
+	report, err := rh.userService.GetReportByReportId(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrReportNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, rh.logger)
+			return
+		}
+	}
+
+	votes, err := rh.userService.GetUserVotes(ctx, []domain.Report{report})
+	if err != nil {
+		response.InternalServerErrorResponse(w, err, rh.logger)
+		return
+	}
+
+	response.SuccessResponse(w, "report votes retrieved successfully", toReportVotesDTO(report, votes[report.ID]), rh.logger)
+}
@@ -5,25 +5,27 @@ import (
 )
 
 type UserDTO struct {
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Avatar    string `json:"avatar"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	UserName  string `json:"user_name"`
-	Location  string `json:"location"`
-	Phone     string `json:"phone"`
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	Avatar      string `json:"avatar"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	UserName    string `json:"user_name"`
+	Location    string `json:"location"`
+	Phone       string `json:"phone"`
+	HideReports bool   `json:"hide_reports"`
 }
 
 func ToUserDTO(user domain.User) UserDTO {
 	return UserDTO{
-		ID:        user.ID.String(),
-		Email:     user.Email,
-		Avatar:    user.AvatarUrl,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		UserName:  user.UserName,
-		Location:  user.Location,
-		Phone:     user.Phone,
+		ID:          user.ID.String(),
+		Email:       user.Email,
+		Avatar:      user.AvatarUrl,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		UserName:    user.UserName,
+		Location:    user.Location,
+		Phone:       user.Phone,
+		HideReports: user.HideReports,
 	}
 }
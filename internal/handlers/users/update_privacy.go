@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/usecases/users"
+	appErrors "github.com/olad5/caution-companion/pkg/errors"
+	response "github.com/olad5/caution-companion/pkg/utils"
+	utils "github.com/olad5/caution-companion/pkg/utils/validation"
+)
+
+func (u UserHandler) UpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Body == nil {
+		response.ErrorResponse(w, appErrors.ErrMissingBody, http.StatusBadRequest)
+		return
+	}
+
+	type requestDTO struct {
+		HideReports *bool `json:"hide_reports" validate:"required"`
+	}
+
+	request, err := response.Decode[requestDTO](r)
+	if err != nil {
+		response.ErrorResponse(w, appErrors.ErrInvalidJson, http.StatusBadRequest)
+		return
+	}
+
+	err = utils.Check(request)
+	if err != nil {
+		response.ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedUser, err := u.userService.UpdatePrivacy(ctx, *request.HideReports)
+	if err != nil {
+		switch {
+		case errors.Is(err, infra.ErrUserNotFound):
+			response.ErrorResponse(w, err.Error(), http.StatusNotFound)
+			return
+		case errors.Is(err, users.ErrInvalidToken):
+			response.ErrorResponse(w, err.Error(), http.StatusUnauthorized)
+			return
+		default:
+			response.InternalServerErrorResponse(w, err, u.logger)
+			return
+		}
+	}
+
+	response.SuccessResponse(w, "privacy settings updated successfully", ToUserDTO(updatedUser), u.logger)
+}
@@ -2,12 +2,19 @@ package infra
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var ErrCacheMiss = errors.New("key not found in cache")
+
 type Cache interface {
 	SetOne(ctx context.Context, key, value string, ttl time.Duration) error
+	// GetOne fails with ErrCacheMiss when the key does not exist
 	GetOne(ctx context.Context, key string) (string, error)
+	// SetOneIfNotExists reports whether the key was set, it is false when
+	// the key already exists
+	SetOneIfNotExists(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
 	GetAllKeysUsingWildCard(ctx context.Context, wildcard string) ([]string, error)
 	DeleteOne(ctx context.Context, key string) error
 	Ping(ctx context.Context) error
@@ -0,0 +1,83 @@
+package lognotifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+// LogNotifier writes notifications as JSON lines instead of delivering
+// them, it stands in for real channels during local development and tests.
+type LogNotifier struct {
+	channel string
+
+	mu  sync.Mutex
+	out io.Writer
+	// file is nil when writing to stdout
+	file *os.File
+}
+
+type entry struct {
+	Channel   string            `json:"channel"`
+	UserID    string            `json:"user_id"`
+	Devices   int               `json:"devices"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// New appends to the file at path, or writes to stdout when path is empty.
+func New(channel, path string) (*LogNotifier, error) {
+	if channel == "" {
+		return &LogNotifier{}, fmt.Errorf("LogNotifier failed to initialize, channel is empty")
+	}
+	if path == "" {
+		return &LogNotifier{channel: channel, out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return &LogNotifier{}, fmt.Errorf("LogNotifier failed to open %s: %w", path, err)
+	}
+	return &LogNotifier{channel: channel, out: file, file: file}, nil
+}
+
+func (l *LogNotifier) Channel() string {
+	return l.channel
+}
+
+func (l *LogNotifier) Notify(ctx context.Context, recipient infra.NotificationRecipient, notification infra.Notification) error {
+	line, err := json.Marshal(entry{
+		Channel:   l.channel,
+		UserID:    recipient.User.ID.String(),
+		Devices:   len(recipient.Devices),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      notification.Data,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding notification: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing notification: %w", err)
+	}
+	return nil
+}
+
+func (l *LogNotifier) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,28 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/olad5/caution-companion/internal/domain"
+)
+
+// Notification is a short message for a user, every channel renders it in
+// its own way.
+type Notification struct {
+	Title string
+	Body  string
+	// Data is passed on to apps so they can open the right screen
+	Data map[string]string
+}
+
+type NotificationRecipient struct {
+	User    domain.User
+	Devices []domain.Device
+}
+
+// Notifier delivers notifications over a single channel.
+type Notifier interface {
+	// Channel is the name users enable or disable the notifier with.
+	Channel() string
+	Notify(ctx context.Context, recipient NotificationRecipient, notification Notification) error
+}
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN lat DOUBLE PRECISION;
+ALTER TABLE reports ADD COLUMN lng DOUBLE PRECISION;
+
+UPDATE reports SET
+    lat = CAST(latitude AS DOUBLE PRECISION),
+    lng = CAST(longitude AS DOUBLE PRECISION)
+WHERE
+    latitude ~ '^[-+]?[0-9]*\.?[0-9]+$' AND longitude ~ '^[-+]?[0-9]*\.?[0-9]+$';
+
+CREATE INDEX reports_lat_lng_idx ON reports (lat, lng);
+CREATE INDEX reports_created_at_idx ON reports (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_created_at_idx;
+DROP INDEX reports_lat_lng_idx;
+ALTER TABLE reports DROP COLUMN lng ;
+ALTER TABLE reports DROP COLUMN lat ;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE incident_types(
+    slug VARCHAR(50) PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    default_severity SMALLINT NOT NULL DEFAULT 1,
+    icon TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO incident_types (slug, display_name, default_severity, icon) VALUES
+    ('robbery', 'Robbery', 3, 'robbery'),
+    ('fire', 'Fire', 4, 'fire'),
+    ('accident', 'Accident', 3, 'accident'),
+    ('cult', 'Cult Activity', 4, 'cult')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE incident_types;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'reported';
+
+CREATE TABLE report_status_history(
+    id UUID PRIMARY KEY,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_by UUID,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX report_status_history_report_id_idx ON report_status_history (report_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE report_status_history;
+ALTER TABLE reports DROP COLUMN status ;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE report_votes(
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    vote VARCHAR(10) NOT NULL CHECK (vote IN ('confirm', 'dispute')),
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (report_id, user_id)
+);
+
+ALTER TABLE reports ADD COLUMN confirmation_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reports ADD COLUMN dispute_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reports ADD COLUMN credibility_score DOUBLE PRECISION NOT NULL DEFAULT 0.5;
+
+CREATE INDEX reports_credibility_score_idx ON reports (credibility_score DESC, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_credibility_score_idx;
+ALTER TABLE reports DROP COLUMN credibility_score ;
+ALTER TABLE reports DROP COLUMN dispute_count ;
+ALTER TABLE reports DROP COLUMN confirmation_count ;
+DROP TABLE report_votes;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE files(
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE report_media(
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (report_id, file_id)
+);
+
+CREATE INDEX report_media_report_id_idx ON report_media (report_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE report_media;
+DROP TABLE files;
+-- +goose StatementEnd
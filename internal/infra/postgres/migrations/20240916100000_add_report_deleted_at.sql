@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX reports_owner_id_idx ON reports (owner_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_owner_id_idx;
+ALTER TABLE reports DROP COLUMN deleted_at ;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE users ADD COLUMN hide_reports BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX reports_owner_id_status_idx ON reports (owner_id, status, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_owner_id_status_idx;
+ALTER TABLE users DROP COLUMN hide_reports ;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(description, '')), 'A') ||
+    setweight(to_tsvector('english', replace(coalesce(incident_type, ''), '_', ' ')), 'B')
+  ) STORED;
+CREATE INDEX reports_search_vector_idx ON reports USING GIN (search_vector);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_search_vector_idx;
+ALTER TABLE reports DROP COLUMN search_vector ;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE INDEX reports_created_at_id_idx ON reports (created_at, id);
+CREATE INDEX reports_incident_type_created_at_idx ON reports (incident_type, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_incident_type_created_at_idx;
+DROP INDEX reports_created_at_id_idx;
+-- +goose StatementEnd
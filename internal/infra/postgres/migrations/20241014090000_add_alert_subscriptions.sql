@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE alert_subscriptions(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    shape TEXT NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+    longitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+    radius_in_meters DOUBLE PRECISION NOT NULL DEFAULT 0,
+    polygon JSONB NOT NULL DEFAULT '[]',
+    incident_types TEXT[] NOT NULL DEFAULT '{}',
+    quiet_hours_start INTEGER NOT NULL DEFAULT 0,
+    quiet_hours_end INTEGER NOT NULL DEFAULT 0,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    min_latitude DOUBLE PRECISION NOT NULL,
+    min_longitude DOUBLE PRECISION NOT NULL,
+    max_latitude DOUBLE PRECISION NOT NULL,
+    max_longitude DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX alert_subscriptions_user_id_idx ON alert_subscriptions (user_id, created_at);
+CREATE INDEX alert_subscriptions_bounds_idx ON alert_subscriptions (min_latitude, max_latitude, min_longitude, max_longitude);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE alert_subscriptions;
+-- +goose StatementEnd
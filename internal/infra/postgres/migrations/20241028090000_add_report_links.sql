@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN duplicate_of UUID REFERENCES reports(id);
+ALTER TABLE reports ADD COLUMN corroboration_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX reports_duplicate_of_idx ON reports (duplicate_of, created_at) WHERE duplicate_of IS NOT NULL;
+
+CREATE TABLE report_links(
+    id UUID PRIMARY KEY,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    primary_id UUID REFERENCES reports(id) ON DELETE CASCADE,
+    previous_primary_id UUID REFERENCES reports(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    actor_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX report_links_report_id_idx ON report_links (report_id, created_at);
+CREATE INDEX report_links_primary_id_idx ON report_links (primary_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE report_links;
+DROP INDEX reports_duplicate_of_idx;
+ALTER TABLE reports DROP COLUMN corroboration_count;
+ALTER TABLE reports DROP COLUMN duplicate_of;
+-- +goose StatementEnd
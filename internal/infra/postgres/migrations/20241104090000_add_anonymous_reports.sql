@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE incident_types ADD COLUMN allows_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE incident_types SET allows_anonymous = TRUE WHERE slug = 'cult';
+
+ALTER TABLE reports ALTER COLUMN owner_id DROP NOT NULL;
+ALTER TABLE reports ADD COLUMN is_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- anonymous reports have no owner_id, the owner is only kept here so that
+-- it is never read along with the report
+CREATE TABLE anonymous_report_owners(
+    report_id UUID PRIMARY KEY REFERENCES reports(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX anonymous_report_owners_owner_id_idx ON anonymous_report_owners (owner_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE anonymous_report_owners;
+DELETE FROM reports WHERE owner_id IS NULL;
+ALTER TABLE reports DROP COLUMN is_anonymous;
+ALTER TABLE reports ALTER COLUMN owner_id SET NOT NULL;
+ALTER TABLE incident_types DROP COLUMN allows_anonymous;
+-- +goose StatementEnd
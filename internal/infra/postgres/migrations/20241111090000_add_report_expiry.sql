@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- reports of a type expire ttl_hours after they were made, 0 means never
+ALTER TABLE incident_types ADD COLUMN ttl_hours INTEGER NOT NULL DEFAULT 168;
+UPDATE incident_types SET ttl_hours = 72 WHERE slug = 'robbery';
+UPDATE incident_types SET ttl_hours = 24 WHERE slug = 'fire';
+UPDATE incident_types SET ttl_hours = 12 WHERE slug = 'accident';
+
+CREATE INDEX reports_live_created_at_idx ON reports (created_at)
+    WHERE status IN ('reported', 'verified') AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_live_created_at_idx;
+ALTER TABLE incident_types DROP COLUMN ttl_hours;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- the state and LGA a report was made in, NULL when it is outside every
+-- known area or has not been geocoded yet
+ALTER TABLE reports ADD COLUMN state_code TEXT;
+ALTER TABLE reports ADD COLUMN lga_code TEXT;
+
+CREATE INDEX reports_state_code_idx ON reports (state_code, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX reports_lga_code_idx ON reports (lga_code, created_at DESC) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_lga_code_idx;
+DROP INDEX reports_state_code_idx;
+ALTER TABLE reports DROP COLUMN lga_code;
+ALTER TABLE reports DROP COLUMN state_code;
+-- +goose StatementEnd
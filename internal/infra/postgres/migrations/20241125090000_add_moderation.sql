@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN hidden_at TIMESTAMP;
+
+ALTER TABLE users ADD COLUMN warning_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP;
+
+CREATE TABLE report_flags(
+    id UUID PRIMARY KEY,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- a user can only have one pending flag on a report
+CREATE UNIQUE INDEX report_flags_pending_idx ON report_flags (report_id, user_id) WHERE resolved_at IS NULL;
+CREATE INDEX report_flags_queue_idx ON report_flags (report_id, created_at) WHERE resolved_at IS NULL;
+
+CREATE TABLE moderation_actions(
+    id UUID PRIMARY KEY,
+    moderator_id UUID NOT NULL REFERENCES users(id),
+    action TEXT NOT NULL,
+    report_id UUID REFERENCES reports(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    suspended_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX moderation_actions_report_id_idx ON moderation_actions (report_id, created_at) WHERE report_id IS NOT NULL;
+CREATE INDEX moderation_actions_user_id_idx ON moderation_actions (user_id, created_at) WHERE user_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE moderation_actions;
+DROP TABLE report_flags;
+ALTER TABLE users DROP COLUMN suspended_until;
+ALTER TABLE users DROP COLUMN warning_count;
+ALTER TABLE reports DROP COLUMN hidden_at;
+-- +goose StatementEnd
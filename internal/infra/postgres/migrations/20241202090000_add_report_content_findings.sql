@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE report_content_findings(
+    id UUID PRIMARY KEY,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    rule TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    matched_text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX report_content_findings_report_id_idx ON report_content_findings (report_id, created_at);
+
+-- flags raised by the content filter have no user
+ALTER TABLE report_flags ALTER COLUMN user_id DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM report_flags WHERE user_id IS NULL;
+ALTER TABLE report_flags ALTER COLUMN user_id SET NOT NULL;
+DROP TABLE report_content_findings;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE reports ADD COLUMN client_id UUID;
+ALTER TABLE reports ADD COLUMN occurred_at TIMESTAMP;
+
+CREATE UNIQUE INDEX reports_client_id_idx ON reports (client_id) WHERE client_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX reports_client_id_idx;
+ALTER TABLE reports DROP COLUMN occurred_at;
+ALTER TABLE reports DROP COLUMN client_id;
+-- +goose StatementEnd
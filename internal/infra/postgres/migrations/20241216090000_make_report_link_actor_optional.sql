@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- anonymous corroborations have no actor
+ALTER TABLE report_links ALTER COLUMN actor_id DROP NOT NULL;
+UPDATE report_links SET actor_id = NULL WHERE actor_id = '00000000-0000-0000-0000-000000000000';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+UPDATE report_links SET actor_id = '00000000-0000-0000-0000-000000000000' WHERE actor_id IS NULL;
+ALTER TABLE report_links ALTER COLUMN actor_id SET NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- reports expire by when the incident happened, occurred_at is only set on
+-- reports queued offline
+CREATE INDEX reports_live_happened_at_idx ON reports ((COALESCE(occurred_at, created_at)))
+    WHERE status IN ('reported', 'verified') AND deleted_at IS NULL;
+DROP INDEX reports_live_created_at_idx;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+CREATE INDEX reports_live_created_at_idx ON reports (created_at)
+    WHERE status IN ('reported', 'verified') AND deleted_at IS NULL;
+DROP INDEX reports_live_happened_at_idx;
+-- +goose StatementEnd
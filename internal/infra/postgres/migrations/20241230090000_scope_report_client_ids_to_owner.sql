@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- client ids are only unique per user, so they do not tell whether another
+-- user made a report. Anonymous reports have no owner_id, their client ids
+-- are kept unique along with their owner.
+DROP INDEX reports_client_id_idx;
+CREATE UNIQUE INDEX reports_owner_client_id_idx ON reports (owner_id, client_id) WHERE client_id IS NOT NULL;
+
+ALTER TABLE anonymous_report_owners ADD COLUMN client_id UUID;
+UPDATE anonymous_report_owners SET client_id = reports.client_id
+    FROM reports WHERE reports.id = anonymous_report_owners.report_id;
+CREATE UNIQUE INDEX anonymous_report_owners_client_id_idx ON anonymous_report_owners (owner_id, client_id) WHERE client_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX anonymous_report_owners_client_id_idx;
+ALTER TABLE anonymous_report_owners DROP COLUMN client_id;
+DROP INDEX reports_owner_client_id_idx;
+CREATE UNIQUE INDEX reports_client_id_idx ON reports (client_id) WHERE client_id IS NOT NULL;
+-- +goose StatementEnd
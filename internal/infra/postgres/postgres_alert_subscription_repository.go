@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+type PostgresAlertSubscriptionRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresAlertSubscriptionRepo(ctx context.Context, connection *sqlx.DB) (*PostgresAlertSubscriptionRepository, error) {
+	if connection == nil {
+		return &PostgresAlertSubscriptionRepository{}, fmt.Errorf("Failed to create PostgresAlertSubscriptionRepository: connection is nil")
+	}
+
+	return &PostgresAlertSubscriptionRepository{connection: connection}, nil
+}
+
+func (p *PostgresAlertSubscriptionRepository) CreateAlertSubscription(ctx context.Context, subscription domain.AlertSubscription) error {
+	const query = `
+    INSERT INTO alert_subscriptions
+      (id, user_id, name, shape, latitude, longitude, radius_in_meters, polygon, incident_types,
+      quiet_hours_start, quiet_hours_end, time_zone, min_latitude, min_longitude, max_latitude,
+      max_longitude, created_at, updated_at)
+    VALUES
+    (:id, :user_id, :name, :shape, :latitude, :longitude, :radius_in_meters, :polygon, :incident_types,
+    :quiet_hours_start, :quiet_hours_end, :time_zone, :min_latitude, :min_longitude, :max_latitude,
+    :max_longitude, :created_at, :updated_at)
+  `
+
+	sqlxSubscription, err := toSqlxAlertSubscription(subscription)
+	if err != nil {
+		return err
+	}
+	_, err = p.connection.NamedExec(query, sqlxSubscription)
+	if err != nil {
+		return fmt.Errorf("error creating alert subscription in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresAlertSubscriptionRepository) UpdateAlertSubscription(ctx context.Context, subscription domain.AlertSubscription) error {
+	const query = `
+  UPDATE
+    alert_subscriptions
+	SET
+		"name" = :name,
+		"shape" = :shape,
+		"latitude" = :latitude,
+		"longitude" = :longitude,
+		"radius_in_meters" = :radius_in_meters,
+		"polygon" = :polygon,
+		"incident_types" = :incident_types,
+		"quiet_hours_start" = :quiet_hours_start,
+		"quiet_hours_end" = :quiet_hours_end,
+		"time_zone" = :time_zone,
+		"min_latitude" = :min_latitude,
+		"min_longitude" = :min_longitude,
+		"max_latitude" = :max_latitude,
+		"max_longitude" = :max_longitude,
+		"updated_at" = :updated_at
+  WHERE
+      id=:id
+  `
+
+	sqlxSubscription, err := toSqlxAlertSubscription(subscription)
+	if err != nil {
+		return err
+	}
+	result, err := p.connection.NamedExec(query, sqlxSubscription)
+	if err != nil {
+		return fmt.Errorf("error updating alert subscription in the db: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error updating alert subscription in the db: %w", err)
+	}
+	if rowsAffected == 0 {
+		return infra.ErrAlertSubscriptionNotFound
+	}
+	return nil
+}
+
+func (p *PostgresAlertSubscriptionRepository) DeleteAlertSubscription(ctx context.Context, subscriptionId uuid.UUID) error {
+	_, err := p.connection.Exec("DELETE FROM alert_subscriptions WHERE id = $1", subscriptionId)
+	if err != nil {
+		return fmt.Errorf("error deleting alert subscription in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresAlertSubscriptionRepository) GetAlertSubscriptionById(ctx context.Context, subscriptionId uuid.UUID) (domain.AlertSubscription, error) {
+	var subscription SqlxAlertSubscription
+
+	err := p.connection.Get(&subscription, "SELECT * FROM alert_subscriptions WHERE id = $1", subscriptionId)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return domain.AlertSubscription{}, infra.ErrAlertSubscriptionNotFound
+		}
+		return domain.AlertSubscription{}, fmt.Errorf("error getting alert subscription by subscriptionId: %w", err)
+	}
+	return toAlertSubscription(subscription)
+}
+
+func (p *PostgresAlertSubscriptionRepository) GetAlertSubscriptionsByUserId(ctx context.Context, userId uuid.UUID) ([]domain.AlertSubscription, error) {
+	var subscriptions []SqlxAlertSubscription
+
+	err := p.connection.Select(&subscriptions,
+		"SELECT * FROM alert_subscriptions WHERE user_id = $1 ORDER BY created_at", userId)
+	if err != nil {
+		return []domain.AlertSubscription{}, fmt.Errorf("error getting alert subscriptions by userId: %w", err)
+	}
+	return toAlertSubscriptions(subscriptions)
+}
+
+func (p *PostgresAlertSubscriptionRepository) CountAlertSubscriptionsByUserId(ctx context.Context, userId uuid.UUID) (int, error) {
+	var count int
+	err := p.connection.Get(&count, "SELECT count(1) FROM alert_subscriptions WHERE user_id = $1", userId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count alert subscriptions by userId: %w", err)
+	}
+	return count, nil
+}
+
+func (p *PostgresAlertSubscriptionRepository) GetAlertSubscriptionsNearPoint(ctx context.Context, lat, lng float64) ([]domain.AlertSubscription, error) {
+	const query = `
+	SELECT
+		*
+	FROM
+		alert_subscriptions
+	WHERE
+		min_latitude <= $1 AND max_latitude >= $1 AND
+		min_longitude <= $2 AND max_longitude >= $2
+	`
+
+	var subscriptions []SqlxAlertSubscription
+	err := p.connection.Select(&subscriptions, query, lat, lng)
+	if err != nil {
+		return []domain.AlertSubscription{}, fmt.Errorf("error getting alert subscriptions near point: %w", err)
+	}
+	return toAlertSubscriptions(subscriptions)
+}
+
+type SqlxAlertSubscription struct {
+	ID              uuid.UUID      `db:"id"`
+	UserID          uuid.UUID      `db:"user_id"`
+	Name            string         `db:"name"`
+	Shape           string         `db:"shape"`
+	Latitude        float64        `db:"latitude"`
+	Longitude       float64        `db:"longitude"`
+	RadiusInMeters  float64        `db:"radius_in_meters"`
+	Polygon         string         `db:"polygon"`
+	IncidentTypes   pq.StringArray `db:"incident_types"`
+	QuietHoursStart int            `db:"quiet_hours_start"`
+	QuietHoursEnd   int            `db:"quiet_hours_end"`
+	TimeZone        string         `db:"time_zone"`
+	MinLatitude     float64        `db:"min_latitude"`
+	MinLongitude    float64        `db:"min_longitude"`
+	MaxLatitude     float64        `db:"max_latitude"`
+	MaxLongitude    float64        `db:"max_longitude"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at"`
+}
+
+func toAlertSubscription(s SqlxAlertSubscription) (domain.AlertSubscription, error) {
+	polygon := []geo.Point{}
+	if err := json.Unmarshal([]byte(s.Polygon), &polygon); err != nil {
+		return domain.AlertSubscription{}, fmt.Errorf("error decoding alert subscription polygon: %w", err)
+	}
+
+	return domain.AlertSubscription{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		Name:            s.Name,
+		Shape:           s.Shape,
+		Latitude:        s.Latitude,
+		Longitude:       s.Longitude,
+		RadiusInMeters:  s.RadiusInMeters,
+		Polygon:         polygon,
+		IncidentTypes:   []string(s.IncidentTypes),
+		QuietHoursStart: s.QuietHoursStart,
+		QuietHoursEnd:   s.QuietHoursEnd,
+		TimeZone:        s.TimeZone,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}, nil
+}
+
+func toAlertSubscriptions(subscriptions []SqlxAlertSubscription) ([]domain.AlertSubscription, error) {
+	result := []domain.AlertSubscription{}
+	for _, element := range subscriptions {
+		subscription, err := toAlertSubscription(element)
+		if err != nil {
+			return []domain.AlertSubscription{}, err
+		}
+		result = append(result, subscription)
+	}
+	return result, nil
+}
+
+func toSqlxAlertSubscription(s domain.AlertSubscription) (SqlxAlertSubscription, error) {
+	polygon := s.Polygon
+	if polygon == nil {
+		polygon = []geo.Point{}
+	}
+	encodedPolygon, err := json.Marshal(polygon)
+	if err != nil {
+		return SqlxAlertSubscription{}, fmt.Errorf("error encoding alert subscription polygon: %w", err)
+	}
+
+	incidentTypes := s.IncidentTypes
+	if incidentTypes == nil {
+		incidentTypes = []string{}
+	}
+
+	box := s.BoundingBox()
+	return SqlxAlertSubscription{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		Name:            s.Name,
+		Shape:           s.Shape,
+		Latitude:        s.Latitude,
+		Longitude:       s.Longitude,
+		RadiusInMeters:  s.RadiusInMeters,
+		Polygon:         string(encodedPolygon),
+		IncidentTypes:   pq.StringArray(incidentTypes),
+		QuietHoursStart: s.QuietHoursStart,
+		QuietHoursEnd:   s.QuietHoursEnd,
+		TimeZone:        s.TimeZone,
+		MinLatitude:     box.MinLatitude,
+		MinLongitude:    box.MinLongitude,
+		MaxLatitude:     box.MaxLatitude,
+		MaxLongitude:    box.MaxLongitude,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}, nil
+}
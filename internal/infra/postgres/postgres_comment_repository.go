@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+type PostgresCommentRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresCommentRepo(ctx context.Context, connection *sqlx.DB) (*PostgresCommentRepository, error) {
+	if connection == nil {
+		return &PostgresCommentRepository{}, fmt.Errorf("Failed to create PostgresCommentRepository: connection is nil")
+	}
+
+	return &PostgresCommentRepository{connection: connection}, nil
+}
+
+func (p *PostgresCommentRepository) CreateComment(ctx context.Context, comment domain.Comment) error {
+	const query = `
+    INSERT INTO comments
+      (id, report_id, author_id, parent_id, body, created_at, updated_at) 
+    VALUES 
+    (:id, :report_id, :author_id, :parent_id, :body, :created_at, :updated_at)
+  `
+
+	_, err := p.connection.NamedExec(query, toSqlxComment(comment))
+	if err != nil {
+		return fmt.Errorf("error creating comment in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresCommentRepository) UpdateComment(ctx context.Context, comment domain.Comment) error {
+	const query = `
+  UPDATE  
+    comments 
+	SET
+		"body" = :body,
+		"updated_at" = :updated_at
+  WHERE 
+      id=:id
+  `
+
+	_, err := p.connection.NamedExec(query, toSqlxComment(comment))
+	if err != nil {
+		return fmt.Errorf("error updating comment in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresCommentRepository) DeleteComment(ctx context.Context, commentId uuid.UUID) error {
+	_, err := p.connection.Exec("DELETE FROM comments WHERE id = $1", commentId)
+	if err != nil {
+		return fmt.Errorf("error deleting comment in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresCommentRepository) GetCommentByCommentId(ctx context.Context, commentId uuid.UUID) (domain.Comment, error) {
+	var comment SqlxComment
+
+	err := p.connection.Get(&comment, "SELECT * FROM comments WHERE id = $1", commentId)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return domain.Comment{}, infra.ErrCommentNotFound
+		}
+		return domain.Comment{}, fmt.Errorf("error getting comment by commentId: %w", err)
+	}
+	return toComment(comment), nil
+}
+
+func (p *PostgresCommentRepository) GetCommentsByReportId(ctx context.Context, reportId uuid.UUID, pageNumber, rowsPerPage int) ([]domain.Comment, error) {
+	offset := (pageNumber - 1) * rowsPerPage
+	var comments []SqlxComment
+
+	query := fmt.Sprintf(`
+    SELECT * FROM comments WHERE report_id = $1 AND parent_id IS NULL
+    ORDER BY created_at
+    OFFSET %d ROWS FETCH NEXT %d ROWS ONLY
+	`, offset, rowsPerPage)
+
+	err := p.connection.Select(&comments, query, reportId)
+	if err != nil {
+		return []domain.Comment{}, fmt.Errorf("error getting comments by reportId: %w", err)
+	}
+
+	result := []domain.Comment{}
+	for _, element := range comments {
+		result = append(result, toComment(element))
+	}
+	return result, nil
+}
+
+func (p *PostgresCommentRepository) GetRepliesByParentIds(ctx context.Context, parentIds []uuid.UUID) ([]domain.Comment, error) {
+	if len(parentIds) == 0 {
+		return []domain.Comment{}, nil
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT * FROM comments WHERE parent_id IN (?) ORDER BY created_at", parentIds)
+	if err != nil {
+		return []domain.Comment{}, fmt.Errorf("error getting replies by parentIds: %w", err)
+	}
+
+	var comments []SqlxComment
+	err = p.connection.Select(&comments, p.connection.Rebind(query), args...)
+	if err != nil {
+		return []domain.Comment{}, fmt.Errorf("error getting replies by parentIds: %w", err)
+	}
+
+	result := []domain.Comment{}
+	for _, element := range comments {
+		result = append(result, toComment(element))
+	}
+	return result, nil
+}
+
+type SqlxComment struct {
+	ID        uuid.UUID     `db:"id"`
+	ReportID  uuid.UUID     `db:"report_id"`
+	AuthorID  uuid.UUID     `db:"author_id"`
+	ParentID  uuid.NullUUID `db:"parent_id"`
+	Body      string        `db:"body"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+}
+
+func toComment(c SqlxComment) domain.Comment {
+	return domain.Comment{
+		ID:        c.ID,
+		ReportID:  c.ReportID,
+		AuthorID:  c.AuthorID,
+		ParentID:  c.ParentID.UUID,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func toSqlxComment(c domain.Comment) SqlxComment {
+	return SqlxComment{
+		ID:        c.ID,
+		ReportID:  c.ReportID,
+		AuthorID:  c.AuthorID,
+		ParentID:  uuid.NullUUID{UUID: c.ParentID, Valid: c.ParentID != uuid.Nil},
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
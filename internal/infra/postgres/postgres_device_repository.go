@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+type PostgresDeviceRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresDeviceRepo(ctx context.Context, connection *sqlx.DB) (*PostgresDeviceRepository, error) {
+	if connection == nil {
+		return &PostgresDeviceRepository{}, fmt.Errorf("Failed to create PostgresDeviceRepository: connection is nil")
+	}
+
+	return &PostgresDeviceRepository{connection: connection}, nil
+}
+
+func (p *PostgresDeviceRepository) SaveDevice(ctx context.Context, device domain.Device) (domain.Device, error) {
+	const query = `
+    INSERT INTO devices
+      (id, user_id, platform, token, p256dh, auth, user_agent, created_at, updated_at)
+    VALUES
+    (:id, :user_id, :platform, :token, :p256dh, :auth, :user_agent, :created_at, :updated_at)
+    ON CONFLICT (token) DO UPDATE SET
+      "user_id" = EXCLUDED.user_id,
+      "platform" = EXCLUDED.platform,
+      "p256dh" = EXCLUDED.p256dh,
+      "auth" = EXCLUDED.auth,
+      "user_agent" = EXCLUDED.user_agent,
+      "updated_at" = EXCLUDED.updated_at
+    RETURNING *
+  `
+
+	rows, err := p.connection.NamedQuery(query, toSqlxDevice(device))
+	if err != nil {
+		return domain.Device{}, fmt.Errorf("error saving device in the db: %w", err)
+	}
+	defer rows.Close()
+
+	var saved SqlxDevice
+	if !rows.Next() {
+		return domain.Device{}, fmt.Errorf("error saving device in the db: %w", rows.Err())
+	}
+	if err := rows.StructScan(&saved); err != nil {
+		return domain.Device{}, fmt.Errorf("error saving device in the db: %w", err)
+	}
+	return toDevice(saved), nil
+}
+
+func (p *PostgresDeviceRepository) DeleteDevice(ctx context.Context, deviceId uuid.UUID) error {
+	_, err := p.connection.Exec("DELETE FROM devices WHERE id = $1", deviceId)
+	if err != nil {
+		return fmt.Errorf("error deleting device in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresDeviceRepository) GetDeviceById(ctx context.Context, deviceId uuid.UUID) (domain.Device, error) {
+	var device SqlxDevice
+
+	err := p.connection.Get(&device, "SELECT * FROM devices WHERE id = $1", deviceId)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return domain.Device{}, infra.ErrDeviceNotFound
+		}
+		return domain.Device{}, fmt.Errorf("error getting device by deviceId: %w", err)
+	}
+	return toDevice(device), nil
+}
+
+func (p *PostgresDeviceRepository) GetDevicesByUserId(ctx context.Context, userId uuid.UUID) ([]domain.Device, error) {
+	var devices []SqlxDevice
+
+	err := p.connection.Select(&devices, "SELECT * FROM devices WHERE user_id = $1 ORDER BY created_at", userId)
+	if err != nil {
+		return []domain.Device{}, fmt.Errorf("error getting devices by userId: %w", err)
+	}
+
+	result := []domain.Device{}
+	for _, element := range devices {
+		result = append(result, toDevice(element))
+	}
+	return result, nil
+}
+
+func (p *PostgresDeviceRepository) CountDevicesByUserId(ctx context.Context, userId uuid.UUID) (int, error) {
+	var count int
+	err := p.connection.Get(&count, "SELECT count(1) FROM devices WHERE user_id = $1", userId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count devices by userId: %w", err)
+	}
+	return count, nil
+}
+
+type SqlxDevice struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Platform  string    `db:"platform"`
+	Token     string    `db:"token"`
+	P256dh    string    `db:"p256dh"`
+	Auth      string    `db:"auth"`
+	UserAgent string    `db:"user_agent"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func toDevice(d SqlxDevice) domain.Device {
+	return domain.Device{
+		ID:        d.ID,
+		UserID:    d.UserID,
+		Platform:  d.Platform,
+		Token:     d.Token,
+		P256dh:    d.P256dh,
+		Auth:      d.Auth,
+		UserAgent: d.UserAgent,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+func toSqlxDevice(d domain.Device) SqlxDevice {
+	return SqlxDevice{
+		ID:        d.ID,
+		UserID:    d.UserID,
+		Platform:  d.Platform,
+		Token:     d.Token,
+		P256dh:    d.P256dh,
+		Auth:      d.Auth,
+		UserAgent: d.UserAgent,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
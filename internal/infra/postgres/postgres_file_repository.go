@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/olad5/caution-companion/internal/domain"
+)
+
+type PostgresFileRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresFileRepo(ctx context.Context, connection *sqlx.DB) (*PostgresFileRepository, error) {
+	if connection == nil {
+		return &PostgresFileRepository{}, fmt.Errorf("Failed to create PostgresFileRepository: connection is nil")
+	}
+
+	return &PostgresFileRepository{connection: connection}, nil
+}
+
+func (p *PostgresFileRepository) CreateFile(ctx context.Context, file domain.File) error {
+	const query = `
+    INSERT INTO files
+      (id, owner_id, url, mime_type, created_at) 
+    VALUES 
+    (:id, :owner_id, :url, :mime_type, :created_at)
+  `
+
+	_, err := p.connection.NamedExec(query, toSqlxFile(file))
+	if err != nil {
+		return fmt.Errorf("error creating file in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresFileRepository) GetFilesByFileIds(ctx context.Context, fileIds []uuid.UUID) ([]domain.File, error) {
+	if len(fileIds) == 0 {
+		return []domain.File{}, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM files WHERE id IN (?)", fileIds)
+	if err != nil {
+		return []domain.File{}, fmt.Errorf("error getting files by fileIds: %w", err)
+	}
+
+	var files []SqlxFile
+	err = p.connection.Select(&files, p.connection.Rebind(query), args...)
+	if err != nil {
+		return []domain.File{}, fmt.Errorf("error getting files by fileIds: %w", err)
+	}
+
+	result := []domain.File{}
+	for _, element := range files {
+		result = append(result, toFile(element))
+	}
+	return result, nil
+}
+
+type SqlxFile struct {
+	ID        uuid.UUID `db:"id"`
+	OwnerID   uuid.UUID `db:"owner_id"`
+	Url       string    `db:"url"`
+	MimeType  string    `db:"mime_type"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toFile(f SqlxFile) domain.File {
+	return domain.File{
+		ID:        f.ID,
+		OwnerID:   f.OwnerID,
+		Url:       f.Url,
+		MimeType:  f.MimeType,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func toSqlxFile(f domain.File) SqlxFile {
+	return SqlxFile{
+		ID:        f.ID,
+		OwnerID:   f.OwnerID,
+		Url:       f.Url,
+		MimeType:  f.MimeType,
+		CreatedAt: f.CreatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+type PostgresIncidentTypeRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresIncidentTypeRepo(ctx context.Context, connection *sqlx.DB) (*PostgresIncidentTypeRepository, error) {
+	if connection == nil {
+		return &PostgresIncidentTypeRepository{}, fmt.Errorf("Failed to create PostgresIncidentTypeRepository: connection is nil")
+	}
+
+	return &PostgresIncidentTypeRepository{connection: connection}, nil
+}
+
+func (p *PostgresIncidentTypeRepository) CreateIncidentType(ctx context.Context, incidentType domain.IncidentType) error {
+	const query = `
+    INSERT INTO incident_types
+      (slug, display_name, default_severity, icon, is_active, allows_anonymous, ttl_hours, created_at, updated_at) 
+    VALUES 
+    (:slug, :display_name, :default_severity, :icon, :is_active, :allows_anonymous, :ttl_hours, :created_at, :updated_at)
+    ON CONFLICT (slug) DO NOTHING
+  `
+
+	result, err := p.connection.NamedExec(query, toSqlxIncidentType(incidentType))
+	if err != nil {
+		return fmt.Errorf("error creating incident type in the db: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrIncidentTypeExists
+	}
+	return nil
+}
+
+func (p *PostgresIncidentTypeRepository) UpdateIncidentType(ctx context.Context, incidentType domain.IncidentType) error {
+	const query = `
+  UPDATE  
+    incident_types 
+	SET
+		"display_name" = :display_name,
+		"default_severity" = :default_severity,
+		"icon" = :icon,
+		"is_active" = :is_active,
+		"allows_anonymous" = :allows_anonymous,
+		"ttl_hours" = :ttl_hours,
+		"updated_at" = :updated_at
+  WHERE 
+      slug=:slug
+  `
+
+	_, err := p.connection.NamedExec(query, toSqlxIncidentType(incidentType))
+	if err != nil {
+		return fmt.Errorf("error updating incident type in the db: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresIncidentTypeRepository) GetIncidentTypeBySlug(ctx context.Context, slug string) (domain.IncidentType, error) {
+	var incidentType SqlxIncidentType
+
+	err := p.connection.Get(&incidentType, "SELECT * FROM incident_types WHERE slug = $1", slug)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return domain.IncidentType{}, infra.ErrIncidentTypeNotFound
+		}
+		return domain.IncidentType{}, fmt.Errorf("error getting incident type by slug: %w", err)
+	}
+	return toIncidentType(incidentType), nil
+}
+
+func (p *PostgresIncidentTypeRepository) GetIncidentTypes(ctx context.Context, includeInactive bool) ([]domain.IncidentType, error) {
+	var incidentTypes []SqlxIncidentType
+
+	query := "SELECT * FROM incident_types WHERE is_active = TRUE ORDER BY display_name"
+	if includeInactive {
+		query = "SELECT * FROM incident_types ORDER BY display_name"
+	}
+
+	err := p.connection.Select(&incidentTypes, query)
+	if err != nil {
+		return []domain.IncidentType{}, fmt.Errorf("error getting incident types: %w", err)
+	}
+
+	result := []domain.IncidentType{}
+	for _, element := range incidentTypes {
+		result = append(result, toIncidentType(element))
+	}
+	return result, nil
+}
+
+type SqlxIncidentType struct {
+	Slug            string    `db:"slug"`
+	DisplayName     string    `db:"display_name"`
+	DefaultSeverity int       `db:"default_severity"`
+	Icon            string    `db:"icon"`
+	IsActive        bool      `db:"is_active"`
+	AllowsAnonymous bool      `db:"allows_anonymous"`
+	TTLHours        int       `db:"ttl_hours"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+func toIncidentType(i SqlxIncidentType) domain.IncidentType {
+	return domain.IncidentType{
+		Slug:            i.Slug,
+		DisplayName:     i.DisplayName,
+		DefaultSeverity: i.DefaultSeverity,
+		Icon:            i.Icon,
+		IsActive:        i.IsActive,
+		AllowsAnonymous: i.AllowsAnonymous,
+		TimeToLive:      time.Duration(i.TTLHours) * time.Hour,
+		CreatedAt:       i.CreatedAt,
+		UpdatedAt:       i.UpdatedAt,
+	}
+}
+
+func toSqlxIncidentType(i domain.IncidentType) SqlxIncidentType {
+	return SqlxIncidentType{
+		Slug:            i.Slug,
+		DisplayName:     i.DisplayName,
+		DefaultSeverity: i.DefaultSeverity,
+		Icon:            i.Icon,
+		IsActive:        i.IsActive,
+		AllowsAnonymous: i.AllowsAnonymous,
+		TTLHours:        int(i.TimeToLive / time.Hour),
+		CreatedAt:       i.CreatedAt,
+		UpdatedAt:       i.UpdatedAt,
+	}
+}
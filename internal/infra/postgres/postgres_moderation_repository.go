@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+type PostgresModerationRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresModerationRepo(ctx context.Context, connection *sqlx.DB) (*PostgresModerationRepository, error) {
+	if connection == nil {
+		return &PostgresModerationRepository{}, fmt.Errorf("Failed to create PostgresModerationRepository: connection is nil")
+	}
+
+	return &PostgresModerationRepository{connection: connection}, nil
+}
+
+func (p *PostgresModerationRepository) CreateReportFlag(ctx context.Context, flag domain.ReportFlag) error {
+	const query = `
+    INSERT INTO report_flags
+      (id, report_id, user_id, reason, note, resolved_at, created_at)
+    VALUES
+    (:id, :report_id, :user_id, :reason, :note, :resolved_at, :created_at)
+    ON CONFLICT (report_id, user_id) WHERE resolved_at IS NULL DO NOTHING
+  `
+	result, err := p.connection.NamedExecContext(ctx, query, toSqlxReportFlag(flag))
+	if err != nil {
+		return fmt.Errorf("error creating report flag: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportAlreadyFlagged
+	}
+	return nil
+}
+
+func (p *PostgresModerationRepository) GetModerationQueue(ctx context.Context, pageNumber, rowsPerPage int) ([]domain.FlaggedReport, error) {
+	offset := (pageNumber - 1) * rowsPerPage
+	const query = `
+    SELECT
+      reports.*,
+      flags.pending_flags,
+      flags.last_flagged_at,
+      (
+        SELECT jsonb_object_agg(reason, count) FROM (
+          SELECT reason, COUNT(1) AS count FROM report_flags
+          WHERE report_flags.report_id = reports.id AND resolved_at IS NULL
+          GROUP BY reason
+        ) AS reasons
+      ) AS reasons
+    FROM (
+      SELECT report_id, COUNT(1) AS pending_flags, MAX(created_at) AS last_flagged_at
+      FROM report_flags
+      WHERE resolved_at IS NULL
+      GROUP BY report_id
+    ) AS flags
+    JOIN reports ON reports.id = flags.report_id
+    WHERE reports.deleted_at IS NULL
+    ORDER BY flags.pending_flags DESC, flags.last_flagged_at DESC, reports.id
+    OFFSET $1 ROWS FETCH NEXT $2 ROWS ONLY
+  `
+
+	var rows []SqlxFlaggedReport
+	if err := p.connection.SelectContext(ctx, &rows, query, offset, rowsPerPage); err != nil {
+		return []domain.FlaggedReport{}, fmt.Errorf("error getting moderation queue: %w", err)
+	}
+
+	result := []domain.FlaggedReport{}
+	for _, row := range rows {
+		reasons := map[string]int{}
+		if err := json.Unmarshal(row.Reasons, &reasons); err != nil {
+			return []domain.FlaggedReport{}, fmt.Errorf("error decoding flag reasons: %w", err)
+		}
+		result = append(result, domain.FlaggedReport{
+			Report:        toReport(row.SqlxReport),
+			PendingFlags:  row.PendingFlags,
+			LastFlaggedAt: row.LastFlaggedAt,
+			Reasons:       reasons,
+		})
+	}
+	return result, nil
+}
+
+func (p *PostgresModerationRepository) CountModerationQueue(ctx context.Context) (int, error) {
+	var count int
+	err := p.connection.GetContext(ctx, &count, `
+    SELECT COUNT(DISTINCT report_flags.report_id)
+    FROM report_flags
+    JOIN reports ON reports.id = report_flags.report_id
+    WHERE report_flags.resolved_at IS NULL AND reports.deleted_at IS NULL`)
+	if err != nil {
+		return 0, fmt.Errorf("error counting moderation queue: %w", err)
+	}
+	return count, nil
+}
+
+func (p *PostgresModerationRepository) ApplyReportAction(ctx context.Context, action domain.ModerationAction) error {
+	var update string
+	args := []interface{}{action.ReportID}
+	switch action.Action {
+	case domain.ModerationActionHide:
+		update = "UPDATE reports SET hidden_at = $2 WHERE id = $1 AND deleted_at IS NULL"
+		args = append(args, action.CreatedAt)
+	case domain.ModerationActionRestore:
+		update = "UPDATE reports SET hidden_at = NULL WHERE id = $1 AND deleted_at IS NULL"
+	case domain.ModerationActionDelete:
+		update = "UPDATE reports SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL"
+		args = append(args, action.CreatedAt)
+	default:
+		return fmt.Errorf("error applying report action: unknown action %q", action.Action)
+	}
+
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error applying report action: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, update, args...)
+	if err != nil {
+		return fmt.Errorf("error applying report action: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportNotFound
+	}
+
+	// the primary the report corroborates is recounted
+	var primaryId uuid.NullUUID
+	err = tx.GetContext(ctx, &primaryId, "SELECT duplicate_of FROM reports WHERE id = $1", action.ReportID)
+	if err != nil {
+		return fmt.Errorf("error applying report action: %w", err)
+	}
+	if primaryId.Valid {
+		if err := refreshCorroborationCount(ctx, tx, primaryId.UUID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE report_flags SET resolved_at = $2 WHERE report_id = $1 AND resolved_at IS NULL",
+		action.ReportID, action.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error resolving report flags: %w", err)
+	}
+
+	if err := createModerationAction(ctx, tx, action); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error applying report action: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresModerationRepository) ApplyUserAction(ctx context.Context, action domain.ModerationAction) error {
+	var update string
+	args := []interface{}{action.UserID}
+	switch action.Action {
+	case domain.ModerationActionWarn:
+		update = "UPDATE users SET warning_count = warning_count + 1 WHERE id = $1"
+	case domain.ModerationActionSuspend:
+		update = "UPDATE users SET suspended_until = $2 WHERE id = $1"
+		args = append(args, action.SuspendedUntil)
+	default:
+		return fmt.Errorf("error applying user action: unknown action %q", action.Action)
+	}
+
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error applying user action: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, update, args...)
+	if err != nil {
+		return fmt.Errorf("error applying user action: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrUserNotFound
+	}
+
+	if err := createModerationAction(ctx, tx, action); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error applying user action: %w", err)
+	}
+	return nil
+}
+
+func createModerationAction(ctx context.Context, tx *sqlx.Tx, action domain.ModerationAction) error {
+	const query = `
+    INSERT INTO moderation_actions
+      (id, moderator_id, action, report_id, user_id, reason, suspended_until, created_at)
+    VALUES
+    (:id, :moderator_id, :action, :report_id, :user_id, :reason, :suspended_until, :created_at)
+  `
+	if _, err := tx.NamedExecContext(ctx, query, toSqlxModerationAction(action)); err != nil {
+		return fmt.Errorf("error recording moderation action: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresModerationRepository) GetModerationActionsByReportId(ctx context.Context, reportId uuid.UUID) ([]domain.ModerationAction, error) {
+	var actions []SqlxModerationAction
+	err := p.connection.SelectContext(ctx, &actions,
+		"SELECT * FROM moderation_actions WHERE report_id = $1 ORDER BY created_at", reportId)
+	if err != nil {
+		return []domain.ModerationAction{}, fmt.Errorf("error getting moderation actions: %w", err)
+	}
+
+	result := []domain.ModerationAction{}
+	for _, action := range actions {
+		result = append(result, toModerationAction(action))
+	}
+	return result, nil
+}
+
+type SqlxReportFlag struct {
+	ID         uuid.UUID    `db:"id"`
+	ReportID   uuid.UUID    `db:"report_id"`
+	UserID     uuid.UUID    `db:"user_id"`
+	Reason     string       `db:"reason"`
+	Note       string       `db:"note"`
+	ResolvedAt sql.NullTime `db:"resolved_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+func toSqlxReportFlag(f domain.ReportFlag) SqlxReportFlag {
+	return SqlxReportFlag{
+		ID:         f.ID,
+		ReportID:   f.ReportID,
+		UserID:     f.UserID,
+		Reason:     f.Reason,
+		Note:       f.Note,
+		ResolvedAt: sql.NullTime{Time: f.ResolvedAt, Valid: !f.ResolvedAt.IsZero()},
+		CreatedAt:  f.CreatedAt,
+	}
+}
+
+type SqlxFlaggedReport struct {
+	SqlxReport
+	PendingFlags  int       `db:"pending_flags"`
+	LastFlaggedAt time.Time `db:"last_flagged_at"`
+	Reasons       []byte    `db:"reasons"`
+}
+
+type SqlxModerationAction struct {
+	ID             uuid.UUID     `db:"id"`
+	ModeratorID    uuid.UUID     `db:"moderator_id"`
+	Action         string        `db:"action"`
+	ReportID       uuid.NullUUID `db:"report_id"`
+	UserID         uuid.NullUUID `db:"user_id"`
+	Reason         string        `db:"reason"`
+	SuspendedUntil sql.NullTime  `db:"suspended_until"`
+	CreatedAt      time.Time     `db:"created_at"`
+}
+
+func toModerationAction(a SqlxModerationAction) domain.ModerationAction {
+	return domain.ModerationAction{
+		ID:             a.ID,
+		ModeratorID:    a.ModeratorID,
+		Action:         a.Action,
+		ReportID:       a.ReportID.UUID,
+		UserID:         a.UserID.UUID,
+		Reason:         a.Reason,
+		SuspendedUntil: a.SuspendedUntil.Time,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+func toSqlxModerationAction(a domain.ModerationAction) SqlxModerationAction {
+	return SqlxModerationAction{
+		ID:             a.ID,
+		ModeratorID:    a.ModeratorID,
+		Action:         a.Action,
+		ReportID:       uuid.NullUUID{UUID: a.ReportID, Valid: a.ReportID != uuid.Nil},
+		UserID:         uuid.NullUUID{UUID: a.UserID, Valid: a.UserID != uuid.Nil},
+		Reason:         a.Reason,
+		SuspendedUntil: sql.NullTime{Time: a.SuspendedUntil, Valid: !a.SuspendedUntil.IsZero()},
+		CreatedAt:      a.CreatedAt,
+	}
+}
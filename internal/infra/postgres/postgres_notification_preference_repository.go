@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/olad5/caution-companion/internal/domain"
+)
+
+type PostgresNotificationPreferenceRepository struct {
+	connection *sqlx.DB
+}
+
+func NewPostgresNotificationPreferenceRepo(ctx context.Context, connection *sqlx.DB) (*PostgresNotificationPreferenceRepository, error) {
+	if connection == nil {
+		return &PostgresNotificationPreferenceRepository{}, fmt.Errorf("Failed to create PostgresNotificationPreferenceRepository: connection is nil")
+	}
+
+	return &PostgresNotificationPreferenceRepository{connection: connection}, nil
+}
+
+func (p *PostgresNotificationPreferenceRepository) SaveNotificationPreferences(
+	ctx context.Context, preferences []domain.NotificationPreference,
+) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error saving notification preferences: %w", err)
+	}
+	defer tx.Rollback()
+
+	const query = `
+    INSERT INTO notification_preferences
+      (user_id, channel, enabled, updated_at)
+    VALUES
+    (:user_id, :channel, :enabled, :updated_at)
+    ON CONFLICT (user_id, channel) DO UPDATE SET
+      "enabled" = EXCLUDED.enabled,
+      "updated_at" = EXCLUDED.updated_at
+  `
+	for _, element := range preferences {
+		if _, err := tx.NamedExecContext(ctx, query, toSqlxNotificationPreference(element)); err != nil {
+			return fmt.Errorf("error saving notification preferences: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error saving notification preferences: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresNotificationPreferenceRepository) GetNotificationPreferences(
+	ctx context.Context, userId uuid.UUID,
+) ([]domain.NotificationPreference, error) {
+	var preferences []SqlxNotificationPreference
+
+	err := p.connection.Select(&preferences,
+		"SELECT * FROM notification_preferences WHERE user_id = $1 ORDER BY channel", userId)
+	if err != nil {
+		return []domain.NotificationPreference{}, fmt.Errorf("error getting notification preferences by userId: %w", err)
+	}
+
+	result := []domain.NotificationPreference{}
+	for _, element := range preferences {
+		result = append(result, toNotificationPreference(element))
+	}
+	return result, nil
+}
+
+type SqlxNotificationPreference struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Channel   string    `db:"channel"`
+	Enabled   bool      `db:"enabled"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func toNotificationPreference(n SqlxNotificationPreference) domain.NotificationPreference {
+	return domain.NotificationPreference{
+		UserID:    n.UserID,
+		Channel:   n.Channel,
+		Enabled:   n.Enabled,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
+
+func toSqlxNotificationPreference(n domain.NotificationPreference) SqlxNotificationPreference {
+	return SqlxNotificationPreference{
+		UserID:    n.UserID,
+		Channel:   n.Channel,
+		Enabled:   n.Enabled,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
@@ -2,14 +2,17 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/olad5/caution-companion/internal/domain"
 	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
 )
 
 type PostgresReportRepository struct {
@@ -24,31 +27,141 @@ func NewPostgresReportRepo(ctx context.Context, connection *sqlx.DB) (*PostgresR
 	return &PostgresReportRepository{connection: connection}, nil
 }
 
-func (p *PostgresReportRepository) CreateReport(ctx context.Context, report domain.Report) error {
-	const query = `
+const createReportQuery = `
     INSERT INTO reports
-      (id, owner_id, incident_type, longitude, latitude, description, created_at, updated_at) 
+      (id, owner_id, incident_type, longitude, latitude, lat, lng, description, status, is_anonymous, duplicate_of, state_code, lga_code, client_id, occurred_at, created_at, updated_at) 
     VALUES 
-    (:id, :owner_id, :incident_type, :longitude, :latitude, :description, :created_at, :updated_at)
+    (:id, :owner_id, :incident_type, :longitude, :latitude, :lat, :lng, :description, :status, :is_anonymous, :duplicate_of, :state_code, :lga_code, :client_id, :occurred_at, :created_at, :updated_at)
+    ON CONFLICT (owner_id, client_id) WHERE client_id IS NOT NULL DO NOTHING
   `
 
-	_, err := p.connection.NamedExec(query, toSqlxReport(report))
+func (p *PostgresReportRepository) CreateReport(ctx context.Context, report domain.Report, findings []domain.ContentFinding) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("error creating report in the db: %w", err)
 	}
+	defer tx.Rollback()
+
+	if err := insertReport(ctx, tx, report, findings); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error creating report in the db: %w", err)
+	}
+	return nil
+}
+
+// insertReport stores the report along with its media, what the content
+// filter found in it and its link to the report it corroborates.
+func insertReport(ctx context.Context, tx *sqlx.Tx, report domain.Report, findings []domain.ContentFinding) error {
+	result, err := tx.NamedExecContext(ctx, createReportQuery, toSqlxReport(report))
+	if err != nil {
+		return fmt.Errorf("error creating report in the db: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportClientIDExists
+	}
+	if err := addReportMedia(ctx, tx, report.Media); err != nil {
+		return err
+	}
+	if err := addContentFindings(ctx, tx, findings); err != nil {
+		return err
+	}
+	if report.DuplicateOf != uuid.Nil {
+		return linkCorroboration(ctx, tx, report)
+	}
+	return nil
+}
+
+// linkCorroboration records the report corroborating the report it is a
+// duplicate of, anonymous reports are recorded without an actor.
+func linkCorroboration(ctx context.Context, tx *sqlx.Tx, report domain.Report) error {
+	link := toSqlxReportLink(domain.ReportLink{
+		ID:        uuid.New(),
+		ReportID:  report.ID,
+		PrimaryID: report.DuplicateOf,
+		Action:    domain.ReportLinkCorroborate,
+		ActorID:   report.OwnerID,
+		CreatedAt: report.CreatedAt,
+	})
+	if _, err := tx.NamedExecContext(ctx, createReportLinkQuery, link); err != nil {
+		return fmt.Errorf("error recording report link: %w", err)
+	}
+	return refreshCorroborationCount(ctx, tx, report.DuplicateOf)
+}
+
+// CreateAnonymousReport stores the owner apart from the report, the report
+// itself is stored without an owner.
+func (p *PostgresReportRepository) CreateAnonymousReport(ctx context.Context, report domain.Report, ownerId uuid.UUID, findings []domain.ContentFinding) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error creating anonymous report: %w", err)
+	}
+	defer tx.Rollback()
+
+	report.OwnerID = uuid.Nil
+	report.IsAnonymous = true
+	if err := insertReport(ctx, tx, report, findings); err != nil {
+		return err
+	}
+
+	// client ids of anonymous reports are kept unique per owner here, the
+	// report itself has no owner
+	result, err := tx.ExecContext(ctx, `
+    INSERT INTO anonymous_report_owners (report_id, owner_id, client_id, created_at) VALUES ($1, $2, $3, $4)
+    ON CONFLICT (owner_id, client_id) WHERE client_id IS NOT NULL DO NOTHING`,
+		report.ID, ownerId, uuid.NullUUID{UUID: report.ClientID, Valid: report.ClientID != uuid.Nil}, report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error storing anonymous report owner: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportClientIDExists
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error creating anonymous report: %w", err)
+	}
 	return nil
 }
 
-func (p *PostgresReportRepository) GetReportsByUserId(ctx context.Context, userId uuid.UUID, pageNumber, rowsPerPage int) ([]domain.Report, error) {
+func (p *PostgresReportRepository) GetAnonymousReportOwner(ctx context.Context, reportId uuid.UUID) (uuid.UUID, error) {
+	var ownerId uuid.UUID
+
+	err := p.connection.Get(&ownerId,
+		"SELECT owner_id FROM anonymous_report_owners WHERE report_id = $1", reportId)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return uuid.Nil, infra.ErrReportNotFound
+		}
+		return uuid.Nil, fmt.Errorf("error getting anonymous report owner: %w", err)
+	}
+	return ownerId, nil
+}
+
+func (p *PostgresReportRepository) CountAnonymousReportsByOwnerSince(ctx context.Context, ownerId uuid.UUID, since time.Time) (int, error) {
+	var count int
+
+	err := p.connection.Get(&count,
+		"SELECT COUNT(1) FROM anonymous_report_owners WHERE owner_id = $1 AND created_at >= $2", ownerId, since)
+	if err != nil {
+		return 0, fmt.Errorf("error counting anonymous reports: %w", err)
+	}
+	return count, nil
+}
+
+func (p *PostgresReportRepository) GetReportsByUserId(ctx context.Context, userId uuid.UUID, status string, pageNumber, rowsPerPage int) ([]domain.Report, error) {
 	offset := (pageNumber - 1) * rowsPerPage
 	var reports []SqlxReport
 
+	conditions, args := userReportsConditions(userId, status)
 	query := fmt.Sprintf(`
-    SELECT * FROM reports WHERE owner_id = $1
+    SELECT * FROM reports WHERE %s
+    ORDER BY created_at DESC
     OFFSET %d ROWS FETCH NEXT %d ROWS ONLY
-	`, offset, rowsPerPage)
+	`, conditions, offset, rowsPerPage)
 
-	err := p.connection.Select(&reports, query, userId)
+	err := p.connection.Select(&reports, p.connection.Rebind(query), args...)
 	if err != nil {
 		if errors.Is(err, ErrRecordNotFound) {
 			return []domain.Report{}, infra.ErrReportNotFound
@@ -64,22 +177,64 @@ func (p *PostgresReportRepository) GetReportsByUserId(ctx context.Context, userI
 	return result, nil
 }
 
-func (p *PostgresReportRepository) GetLatestReports(ctx context.Context, pageNumber, rowsPerPage int) ([]domain.Report, error) {
-	offset := (pageNumber - 1) * rowsPerPage
+func (p *PostgresReportRepository) CountReportsByUserId(ctx context.Context, userId uuid.UUID, status string) (int, error) {
+	conditions, args := userReportsConditions(userId, status)
+	query := "SELECT count(1) FROM reports WHERE " + conditions
+
+	var count int
+	err := p.connection.Get(&count, p.connection.Rebind(query), args...)
+	if err != nil {
+		return 0, fmt.Errorf("error counting reports by userId: %w", err)
+	}
+	return count, nil
+}
+
+// userReportsConditions filters by status only when one is given.
+func userReportsConditions(userId uuid.UUID, status string) (string, []interface{}) {
+	conditions := []string{"owner_id = ?", "deleted_at IS NULL", "hidden_at IS NULL"}
+	args := []interface{}{userId}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func (p *PostgresReportRepository) GetReportFeed(ctx context.Context, q infra.ReportFeedQuery, sortBy string, after infra.ReportFeedCursor, limit int) ([]domain.Report, error) {
 	var reports []SqlxReport
 
+	conditions, args := reportFeedConditions(q)
+	orderBy := "created_at DESC, id DESC"
+	switch sortBy {
+	case infra.ReportSortOldest:
+		orderBy = "created_at ASC, id ASC"
+		if !after.IsZero() {
+			conditions = append(conditions, "(created_at, id) > (?, ?)")
+			args = append(args, after.CreatedAt, after.ID)
+		}
+	case infra.ReportSortCredibility:
+		orderBy = "credibility_score DESC, created_at DESC, id DESC"
+		if !after.IsZero() {
+			conditions = append(conditions, "(credibility_score, created_at, id) < (?, ?, ?)")
+			args = append(args, after.CredibilityScore, after.CreatedAt, after.ID)
+		}
+	default:
+		if !after.IsZero() {
+			conditions = append(conditions, "(created_at, id) < (?, ?)")
+			args = append(args, after.CreatedAt, after.ID)
+		}
+	}
+
 	query := fmt.Sprintf(`
-    SELECT * FROM reports 
-    ORDER BY created_at
-    OFFSET %d ROWS FETCH NEXT %d ROWS ONLY
-	`, offset, rowsPerPage)
+    SELECT * FROM reports
+    WHERE %s
+    ORDER BY %s
+    FETCH FIRST %d ROWS ONLY
+	`, strings.Join(conditions, " AND "), orderBy, limit)
 
-	err := p.connection.Select(&reports, query)
+	err := p.connection.Select(&reports, p.connection.Rebind(query), args...)
 	if err != nil {
-		if errors.Is(err, ErrRecordNotFound) {
-			return []domain.Report{}, infra.ErrReportNotFound
-		}
-		return []domain.Report{}, fmt.Errorf("error getting latest reports: %w", err)
+		return []domain.Report{}, fmt.Errorf("error getting report feed: %w", err)
 	}
 
 	result := []domain.Report{}
@@ -90,6 +245,46 @@ func (p *PostgresReportRepository) GetLatestReports(ctx context.Context, pageNum
 	return result, nil
 }
 
+func reportFeedConditions(q infra.ReportFeedQuery) ([]string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL", "hidden_at IS NULL", "duplicate_of IS NULL"}
+	args := []interface{}{}
+	if len(q.IncidentTypes) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(q.IncidentTypes)), ", ")
+		conditions = append(conditions, "incident_type IN ("+placeholders+")")
+		for _, incidentType := range q.IncidentTypes {
+			args = append(args, incidentType)
+		}
+	}
+	if q.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, q.Status)
+	} else {
+		// expired reports are only listed when asked for
+		conditions = append(conditions, "status <> 'expired'")
+	}
+	if q.OwnerID != uuid.Nil {
+		conditions = append(conditions, "owner_id = ?")
+		args = append(args, q.OwnerID)
+	}
+	if q.StateCode != "" {
+		conditions = append(conditions, "state_code = ?")
+		args = append(args, q.StateCode)
+	}
+	if q.LGACode != "" {
+		conditions = append(conditions, "lga_code = ?")
+		args = append(args, q.LGACode)
+	}
+	if !q.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, q.CreatedAfter)
+	}
+	if !q.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, q.CreatedBefore)
+	}
+	return conditions, args
+}
+
 func (p *PostgresReportRepository) GetReportByReportId(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
 	var report SqlxReport
 
@@ -103,15 +298,885 @@ func (p *PostgresReportRepository) GetReportByReportId(ctx context.Context, repo
 	return toReport(report), nil
 }
 
-func (p *PostgresReportRepository) Count(ctx context.Context) (int, error) {
+func (p *PostgresReportRepository) GetReportByClientId(ctx context.Context, ownerId, clientId uuid.UUID) (domain.Report, error) {
+	var report SqlxReport
+
+	err := p.connection.GetContext(ctx, &report, `
+    SELECT reports.* FROM reports
+    LEFT JOIN anonymous_report_owners ON anonymous_report_owners.report_id = reports.id
+    WHERE reports.client_id = $2 AND (reports.owner_id = $1 OR anonymous_report_owners.owner_id = $1)
+    ORDER BY reports.created_at
+    LIMIT 1`, ownerId, clientId)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return domain.Report{}, infra.ErrReportNotFound
+		}
+		return domain.Report{}, fmt.Errorf("error getting report by clientId: %w", err)
+	}
+	return toReport(report), nil
+}
+
+func (p *PostgresReportRepository) UpdateReport(ctx context.Context, report domain.Report, findings []domain.ContentFinding) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error updating report: %w", err)
+	}
+	defer tx.Rollback()
+
+	const query = `
+    UPDATE reports SET
+      incident_type = :incident_type,
+      longitude = :longitude,
+      latitude = :latitude,
+      lat = :lat,
+      lng = :lng,
+      state_code = :state_code,
+      lga_code = :lga_code,
+      description = :description,
+      updated_at = :updated_at,
+      deleted_at = :deleted_at
+    WHERE id = :id
+  `
+
+	result, err := tx.NamedExecContext(ctx, query, toSqlxReport(report))
+	if err != nil {
+		return fmt.Errorf("error updating report: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportNotFound
+	}
+	if err := addContentFindings(ctx, tx, findings); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error updating report: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresReportRepository) GetNearbyReports(ctx context.Context, q infra.NearbyReportsQuery, pageNumber, rowsPerPage int) ([]domain.NearbyReport, error) {
+	offset := (pageNumber - 1) * rowsPerPage
+	var reports []SqlxNearbyReport
+
+	box := geo.BoundingBoxAround(q.Latitude, q.Longitude, q.RadiusInMeters)
+	conditions := []string{
+		"lat BETWEEN ? AND ?", "lng BETWEEN ? AND ?", "deleted_at IS NULL", "hidden_at IS NULL", "duplicate_of IS NULL", "status <> 'expired'",
+	}
+	args := []interface{}{
+		q.Latitude, q.Latitude, q.Longitude,
+		box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude,
+	}
+	if q.IncidentType != "" {
+		conditions = append(conditions, "incident_type = ?")
+		args = append(args, q.IncidentType)
+	}
+	if !q.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, q.CreatedAfter)
+	}
+	if !q.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, q.CreatedBefore)
+	}
+	args = append(args, q.RadiusInMeters)
+
+	query := fmt.Sprintf(`
+    SELECT * FROM (
+      SELECT *, %s AS distance FROM reports
+      WHERE %s
+    ) AS nearby
+    WHERE distance <= ?
+    ORDER BY distance
+    OFFSET %d ROWS FETCH NEXT %d ROWS ONLY
+	`, haversineDistanceSql, strings.Join(conditions, " AND "), offset, rowsPerPage)
+
+	err := p.connection.Select(&reports, p.connection.Rebind(query), args...)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return []domain.NearbyReport{}, infra.ErrReportNotFound
+		}
+		return []domain.NearbyReport{}, fmt.Errorf("error getting nearby reports: %w", err)
+	}
+
+	result := []domain.NearbyReport{}
+	for _, element := range reports {
+		result = append(result, domain.NearbyReport{
+			Report:           toReport(element.SqlxReport),
+			DistanceInMeters: element.Distance,
+		})
+	}
+
+	return result, nil
+}
+
+func (p *PostgresReportRepository) SearchReports(ctx context.Context, q infra.SearchReportsQuery, pageNumber, rowsPerPage int) ([]domain.ReportSearchResult, error) {
+	offset := (pageNumber - 1) * rowsPerPage
+	var reports []SqlxReportSearchResult
+
+	conditions := []string{"search_vector @@ search_query", "deleted_at IS NULL", "hidden_at IS NULL", "duplicate_of IS NULL", "status <> 'expired'"}
+	args := []interface{}{q.Text}
+	if q.IncidentType != "" {
+		conditions = append(conditions, "incident_type = ?")
+		args = append(args, q.IncidentType)
+	}
+	if !q.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, q.CreatedAfter)
+	}
+	if !q.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, q.CreatedBefore)
+	}
+
+	query := fmt.Sprintf(`
+    SELECT
+      reports.*,
+      ts_rank(search_vector, search_query) AS rank,
+      ts_headline('english', description, search_query,
+        'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+    FROM reports, websearch_to_tsquery('english', ?) AS search_query
+    WHERE %s
+    ORDER BY rank DESC, created_at DESC
+    OFFSET %d ROWS FETCH NEXT %d ROWS ONLY
+	`, strings.Join(conditions, " AND "), offset, rowsPerPage)
+
+	err := p.connection.Select(&reports, p.connection.Rebind(query), args...)
+	if err != nil {
+		return []domain.ReportSearchResult{}, fmt.Errorf("error searching reports: %w", err)
+	}
+
+	result := []domain.ReportSearchResult{}
+	for _, element := range reports {
+		result = append(result, domain.ReportSearchResult{
+			Report:  toReport(element.SqlxReport),
+			Rank:    element.Rank,
+			Snippet: element.Snippet,
+		})
+	}
+
+	return result, nil
+}
+
+func (p *PostgresReportRepository) CountReportsInBoundingBox(ctx context.Context, box geo.BoundingBox) (int, error) {
 	const q = `
 	SELECT
 		count(1)
 	FROM
-		reports`
+		reports
+	WHERE
+		lat BETWEEN $1 AND $2 AND lng BETWEEN $3 AND $4 AND deleted_at IS NULL AND hidden_at IS NULL AND duplicate_of IS NULL AND status <> 'expired'`
 
 	var count int
-	if err := p.connection.Get(&count, q); err != nil {
+	err := p.connection.Get(&count, q, box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude)
+	if err != nil {
+		return 0, fmt.Errorf("error counting reports in bounding box: %w", err)
+	}
+	return count, nil
+}
+
+func (p *PostgresReportRepository) GetReportsInBoundingBox(ctx context.Context, box geo.BoundingBox, limit int) ([]domain.Report, error) {
+	var reports []SqlxReport
+
+	query := fmt.Sprintf(`
+    SELECT * FROM reports
+    WHERE lat BETWEEN $1 AND $2 AND lng BETWEEN $3 AND $4 AND deleted_at IS NULL AND hidden_at IS NULL AND duplicate_of IS NULL AND status <> 'expired'
+    ORDER BY created_at DESC
+    FETCH FIRST %d ROWS ONLY
+	`, limit)
+
+	err := p.connection.Select(&reports, query, box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude)
+	if err != nil {
+		return []domain.Report{}, fmt.Errorf("error getting reports in bounding box: %w", err)
+	}
+
+	result := []domain.Report{}
+	for _, element := range reports {
+		result = append(result, toReport(element))
+	}
+
+	return result, nil
+}
+
+func (p *PostgresReportRepository) GetReportClustersInBoundingBox(ctx context.Context, box geo.BoundingBox, cellSizeInDegrees float64) ([]domain.ReportCluster, error) {
+	type clusterRow struct {
+		CellY        int64   `db:"cell_y"`
+		CellX        int64   `db:"cell_x"`
+		IncidentType string  `db:"incident_type"`
+		Count        int     `db:"count"`
+		LatSum       float64 `db:"lat_sum"`
+		LngSum       float64 `db:"lng_sum"`
+	}
+	var rows []clusterRow
+
+	const query = `
+    SELECT
+      FLOOR(lat / $1)::BIGINT AS cell_y,
+      FLOOR(lng / $1)::BIGINT AS cell_x,
+      incident_type,
+      COUNT(1) AS count,
+      SUM(lat) AS lat_sum,
+      SUM(lng) AS lng_sum
+    FROM reports
+    WHERE lat BETWEEN $2 AND $3 AND lng BETWEEN $4 AND $5 AND deleted_at IS NULL AND hidden_at IS NULL AND duplicate_of IS NULL AND status <> 'expired'
+    GROUP BY cell_y, cell_x, incident_type
+    ORDER BY cell_y, cell_x
+	`
+
+	err := p.connection.Select(&rows, query, cellSizeInDegrees,
+		box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude)
+	if err != nil {
+		return []domain.ReportCluster{}, fmt.Errorf("error getting report clusters: %w", err)
+	}
+
+	type cell struct{ y, x int64 }
+	type aggregate struct {
+		cluster        domain.ReportCluster
+		latSum, lngSum float64
+	}
+	cells := map[cell]*aggregate{}
+	order := []cell{}
+	for _, row := range rows {
+		key := cell{row.CellY, row.CellX}
+		agg, ok := cells[key]
+		if !ok {
+			agg = &aggregate{cluster: domain.ReportCluster{IncidentTypes: map[string]int{}}}
+			cells[key] = agg
+			order = append(order, key)
+		}
+		agg.cluster.Count += row.Count
+		agg.cluster.IncidentTypes[row.IncidentType] += row.Count
+		agg.latSum += row.LatSum
+		agg.lngSum += row.LngSum
+	}
+
+	result := []domain.ReportCluster{}
+	for _, key := range order {
+		agg := cells[key]
+		agg.cluster.Latitude = agg.latSum / float64(agg.cluster.Count)
+		agg.cluster.Longitude = agg.lngSum / float64(agg.cluster.Count)
+		result = append(result, agg.cluster)
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) UpdateReportStatus(ctx context.Context, change domain.ReportStatusChange) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error updating report status: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE reports SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4",
+		change.ToStatus, change.CreatedAt, change.ReportID, change.FromStatus)
+	if err != nil {
+		return fmt.Errorf("error updating report status: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportStatusConflict
+	}
+
+	const query = `
+    INSERT INTO report_status_history
+      (id, report_id, from_status, to_status, changed_by, note, created_at) 
+    VALUES 
+    (:id, :report_id, :from_status, :to_status, :changed_by, :note, :created_at)
+  `
+	_, err = tx.NamedExecContext(ctx, query, toSqlxReportStatusChange(change))
+	if err != nil {
+		return fmt.Errorf("error recording report status history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error updating report status: %w", err)
+	}
+	return nil
+}
+
+// reportExpiryNote is left in the status history of expired reports.
+const reportExpiryNote = "expired after the time to live of its incident type"
+
+// ExpireReports moves up to limit live reports older than the time to live
+// of their incident type to expired, recording the change in their status
+// history, and returns them.
+func (p *PostgresReportRepository) ExpireReports(ctx context.Context, now time.Time, limit int) ([]domain.Report, error) {
+	var reports []SqlxReport
+
+	const query = `
+    WITH candidates AS (
+      SELECT reports.id, reports.status FROM reports
+      JOIN incident_types ON incident_types.slug = reports.incident_type
+      WHERE reports.status IN ('reported', 'verified') AND reports.deleted_at IS NULL
+        AND incident_types.ttl_hours > 0
+        AND COALESCE(reports.occurred_at, reports.created_at) < $1::TIMESTAMP - incident_types.ttl_hours * INTERVAL '1 hour'
+      ORDER BY COALESCE(reports.occurred_at, reports.created_at)
+      LIMIT $2
+      FOR UPDATE OF reports SKIP LOCKED
+    ), history AS (
+      INSERT INTO report_status_history
+        (id, report_id, from_status, to_status, changed_by, note, created_at)
+      SELECT gen_random_uuid(), id, status, 'expired', NULL, $3, $1 FROM candidates
+    )
+    UPDATE reports SET status = 'expired', updated_at = $1
+    FROM candidates WHERE reports.id = candidates.id
+    RETURNING reports.*
+  `
+	err := p.connection.SelectContext(ctx, &reports, query, now, limit, reportExpiryNote)
+	if err != nil {
+		return []domain.Report{}, fmt.Errorf("error expiring reports: %w", err)
+	}
+
+	result := []domain.Report{}
+	for _, element := range reports {
+		result = append(result, toReport(element))
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) GetReportStatusHistory(ctx context.Context, reportId uuid.UUID) ([]domain.ReportStatusChange, error) {
+	var changes []SqlxReportStatusChange
+
+	err := p.connection.Select(&changes,
+		"SELECT * FROM report_status_history WHERE report_id = $1 ORDER BY created_at", reportId)
+	if err != nil {
+		return []domain.ReportStatusChange{}, fmt.Errorf("error getting report status history: %w", err)
+	}
+
+	result := []domain.ReportStatusChange{}
+	for _, element := range changes {
+		result = append(result, toReportStatusChange(element))
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) CreateReportVote(ctx context.Context, vote domain.ReportVote) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error creating report vote: %w", err)
+	}
+	defer tx.Rollback()
+
+	const query = `
+    INSERT INTO report_votes
+      (report_id, user_id, vote, created_at) 
+    VALUES 
+    (:report_id, :user_id, :vote, :created_at)
+    ON CONFLICT DO NOTHING
+  `
+	result, err := tx.NamedExecContext(ctx, query, toSqlxReportVote(vote))
+	if err != nil {
+		return fmt.Errorf("error creating report vote: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportVoteExists
+	}
+
+	if err := refreshReportVoteCounts(ctx, tx, vote.ReportID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error creating report vote: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresReportRepository) DeleteReportVote(ctx context.Context, reportId, userId uuid.UUID) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting report vote: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"DELETE FROM report_votes WHERE report_id = $1 AND user_id = $2", reportId, userId)
+	if err != nil {
+		return fmt.Errorf("error deleting report vote: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportVoteNotFound
+	}
+
+	if err := refreshReportVoteCounts(ctx, tx, reportId); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error deleting report vote: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresReportRepository) GetUserVotes(ctx context.Context, userId uuid.UUID, reportIds []uuid.UUID) (map[uuid.UUID]string, error) {
+	result := map[uuid.UUID]string{}
+	if len(reportIds) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT * FROM report_votes WHERE user_id = ? AND report_id IN (?)", userId, reportIds)
+	if err != nil {
+		return result, fmt.Errorf("error getting user votes: %w", err)
+	}
+
+	var votes []SqlxReportVote
+	err = p.connection.Select(&votes, p.connection.Rebind(query), args...)
+	if err != nil {
+		return result, fmt.Errorf("error getting user votes: %w", err)
+	}
+
+	for _, vote := range votes {
+		result[vote.ReportID] = vote.Vote
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) AddReportMedia(ctx context.Context, media []domain.ReportMedia) error {
+	if len(media) == 0 {
+		return nil
+	}
+
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error adding report media: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := addReportMedia(ctx, tx, media); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error adding report media: %w", err)
+	}
+	return nil
+}
+
+func addReportMedia(ctx context.Context, tx *sqlx.Tx, media []domain.ReportMedia) error {
+	const query = `
+    INSERT INTO report_media
+      (report_id, file_id, owner_id, url, mime_type, position, created_at) 
+    VALUES 
+    (:report_id, :file_id, :owner_id, :url, :mime_type, :position, :created_at)
+    ON CONFLICT DO NOTHING
+  `
+	for _, element := range media {
+		if _, err := tx.NamedExecContext(ctx, query, toSqlxReportMedia(element)); err != nil {
+			return fmt.Errorf("error adding report media: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *PostgresReportRepository) GetReportMedia(ctx context.Context, reportIds []uuid.UUID) ([]domain.ReportMedia, error) {
+	if len(reportIds) == 0 {
+		return []domain.ReportMedia{}, nil
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT * FROM report_media WHERE report_id IN (?) ORDER BY report_id, position", reportIds)
+	if err != nil {
+		return []domain.ReportMedia{}, fmt.Errorf("error getting report media: %w", err)
+	}
+
+	var media []SqlxReportMedia
+	err = p.connection.Select(&media, p.connection.Rebind(query), args...)
+	if err != nil {
+		return []domain.ReportMedia{}, fmt.Errorf("error getting report media: %w", err)
+	}
+
+	result := []domain.ReportMedia{}
+	for _, element := range media {
+		result = append(result, toReportMedia(element))
+	}
+	return result, nil
+}
+
+// LinkReport attaches the report to link.PrimaryID, or detaches it when
+// PrimaryID is uuid.Nil. Reports corroborating a merged report are moved to
+// the new primary report as well so corroborations never form chains.
+const createReportLinkQuery = `
+    INSERT INTO report_links
+      (id, report_id, primary_id, previous_primary_id, action, actor_id, created_at) 
+    VALUES 
+    (:id, :report_id, :primary_id, :previous_primary_id, :action, :actor_id, :created_at)
+  `
+
+func (p *PostgresReportRepository) LinkReport(ctx context.Context, link domain.ReportLink) error {
+	tx, err := p.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error linking report: %w", err)
+	}
+	defer tx.Rollback()
+
+	sqlxLink := toSqlxReportLink(link)
+	result, err := tx.ExecContext(ctx,
+		"UPDATE reports SET duplicate_of = $1, updated_at = $2 WHERE id = $3",
+		sqlxLink.PrimaryID, link.CreatedAt, link.ReportID)
+	if err != nil {
+		return fmt.Errorf("error linking report: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportNotFound
+	}
+
+	if _, err := tx.NamedExecContext(ctx, createReportLinkQuery, sqlxLink); err != nil {
+		return fmt.Errorf("error recording report link: %w", err)
+	}
+
+	affected := []uuid.UUID{link.ReportID}
+	if link.PrimaryID != uuid.Nil {
+		affected = append(affected, link.PrimaryID)
+
+		const moveCorroborations = `
+      WITH moved AS (
+        UPDATE reports SET duplicate_of = $1, updated_at = $2
+        WHERE duplicate_of = $3
+        RETURNING id
+      )
+      INSERT INTO report_links
+        (id, report_id, primary_id, previous_primary_id, action, actor_id, created_at)
+      SELECT gen_random_uuid(), id, $1, $3, $4, $5, $2 FROM moved
+    `
+		_, err := tx.ExecContext(ctx, moveCorroborations,
+			link.PrimaryID, link.CreatedAt, link.ReportID, link.Action, sqlxLink.ActorID)
+		if err != nil {
+			return fmt.Errorf("error moving corroborations: %w", err)
+		}
+	}
+	if link.PreviousPrimaryID != uuid.Nil {
+		affected = append(affected, link.PreviousPrimaryID)
+	}
+	for _, reportId := range affected {
+		if err := refreshCorroborationCount(ctx, tx, reportId); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error linking report: %w", err)
+	}
+	return nil
+}
+
+// GetReportLinks returns every link where the report was either the one
+// being linked or the primary report, oldest first.
+func (p *PostgresReportRepository) GetReportLinks(ctx context.Context, reportId uuid.UUID) ([]domain.ReportLink, error) {
+	var links []SqlxReportLink
+
+	err := p.connection.Select(&links, `
+    SELECT * FROM report_links
+    WHERE report_id = $1 OR primary_id = $1 OR previous_primary_id = $1
+    ORDER BY created_at`, reportId)
+	if err != nil {
+		return []domain.ReportLink{}, fmt.Errorf("error getting report links: %w", err)
+	}
+
+	result := []domain.ReportLink{}
+	for _, element := range links {
+		result = append(result, toReportLink(element))
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) GetCorroborations(ctx context.Context, primaryId uuid.UUID) ([]domain.Report, error) {
+	var reports []SqlxReport
+
+	err := p.connection.Select(&reports,
+		"SELECT * FROM reports WHERE duplicate_of = $1 AND deleted_at IS NULL AND hidden_at IS NULL ORDER BY created_at", primaryId)
+	if err != nil {
+		return []domain.Report{}, fmt.Errorf("error getting corroborations: %w", err)
+	}
+
+	result := []domain.Report{}
+	for _, element := range reports {
+		result = append(result, toReport(element))
+	}
+	return result, nil
+}
+
+func refreshCorroborationCount(ctx context.Context, tx *sqlx.Tx, reportId uuid.UUID) error {
+	const query = `
+    UPDATE reports SET
+      corroboration_count = (
+        SELECT COUNT(1) FROM reports AS corroborations
+        WHERE corroborations.duplicate_of = $1 AND corroborations.deleted_at IS NULL
+          AND corroborations.hidden_at IS NULL
+      )
+    WHERE id = $1
+  `
+	if _, err := tx.ExecContext(ctx, query, reportId); err != nil {
+		return fmt.Errorf("error refreshing corroboration count: %w", err)
+	}
+	return nil
+}
+
+// refreshReportVoteCounts recomputes the denormalised vote counters of a
+// report. The credibility score is a smoothed confirmation ratio so reports
+// without votes start at 0.5 instead of the extremes.
+func refreshReportVoteCounts(ctx context.Context, tx *sqlx.Tx, reportId uuid.UUID) error {
+	const query = `
+    UPDATE reports SET
+      confirmation_count = v.confirmations,
+      dispute_count = v.disputes,
+      credibility_score = (v.confirmations + 1.0) / (v.confirmations + v.disputes + 2.0)
+    FROM (
+      SELECT
+        COUNT(1) FILTER (WHERE vote = 'confirm') AS confirmations,
+        COUNT(1) FILTER (WHERE vote = 'dispute') AS disputes
+      FROM report_votes WHERE report_id = $1
+    ) AS v
+    WHERE id = $1
+  `
+	if _, err := tx.ExecContext(ctx, query, reportId); err != nil {
+		return fmt.Errorf("error refreshing report vote counts: %w", err)
+	}
+	return nil
+}
+
+// GetReportStats runs one grouped count per breakdown. Times are bucketed
+// in q.Location, created_at is stored in UTC.
+func (p *PostgresReportRepository) GetReportStats(ctx context.Context, q infra.ReportStatsQuery) (domain.ReportStats, error) {
+	previousFrom := q.From.Add(-q.To.Sub(q.From))
+	stats := domain.ReportStats{
+		From:              q.From.In(q.Location),
+		To:                q.To.In(q.Location),
+		PreviousFrom:      previousFrom.In(q.Location),
+		TimeZone:          q.Location.String(),
+		CellSizeInDegrees: q.CellSizeInDegrees,
+		ByIncidentType:    []domain.IncidentTypeCount{},
+		ByHourOfDay:       make([]int, 24),
+		ByCell:            []domain.CellCount{},
+	}
+	localTime := "((created_at AT TIME ZONE 'UTC') AT TIME ZONE ?)"
+
+	type incidentTypeRow struct {
+		IncidentType  string `db:"incident_type"`
+		Count         int    `db:"count"`
+		PreviousCount int    `db:"previous_count"`
+	}
+	var incidentTypeRows []incidentTypeRow
+	conditions, args := reportStatsConditions(q, previousFrom, q.To)
+	query := `
+    SELECT
+      incident_type,
+      COUNT(1) FILTER (WHERE created_at >= ?) AS count,
+      COUNT(1) FILTER (WHERE created_at < ?) AS previous_count
+    FROM reports
+    WHERE ` + strings.Join(conditions, " AND ") + `
+    GROUP BY incident_type
+    ORDER BY count DESC, incident_type`
+	err := p.connection.SelectContext(ctx, &incidentTypeRows, p.connection.Rebind(query),
+		append([]interface{}{q.From, q.From}, args...)...)
+	if err != nil {
+		return domain.ReportStats{}, fmt.Errorf("error counting reports by incident type: %w", err)
+	}
+	for _, row := range incidentTypeRows {
+		stats.Total += row.Count
+		stats.PreviousTotal += row.PreviousCount
+		stats.ByIncidentType = append(stats.ByIncidentType, domain.IncidentTypeCount(row))
+	}
+
+	conditions, args = reportStatsConditions(q, q.From, q.To)
+	where := strings.Join(conditions, " AND ")
+	for _, bucket := range []struct {
+		unit   string
+		result *[]domain.TimeBucketCount
+	}{
+		{"day", &stats.ByDay},
+		{"week", &stats.ByWeek},
+	} {
+		var rows []struct {
+			Start time.Time `db:"start"`
+			Count int       `db:"count"`
+		}
+		query := fmt.Sprintf(`
+      SELECT date_trunc('%s', %s) AS start, COUNT(1) AS count
+      FROM reports WHERE %s
+      GROUP BY 1 ORDER BY 1`, bucket.unit, localTime, where)
+		err := p.connection.SelectContext(ctx, &rows, p.connection.Rebind(query),
+			append([]interface{}{q.Location.String()}, args...)...)
+		if err != nil {
+			return domain.ReportStats{}, fmt.Errorf("error counting reports by %s: %w", bucket.unit, err)
+		}
+		*bucket.result = []domain.TimeBucketCount{}
+		for _, row := range rows {
+			// the database hands back the local wall time
+			start := time.Date(row.Start.Year(), row.Start.Month(), row.Start.Day(), 0, 0, 0, 0, q.Location)
+			*bucket.result = append(*bucket.result, domain.TimeBucketCount{Start: start, Count: row.Count})
+		}
+	}
+
+	var hourRows []struct {
+		Hour  int `db:"hour"`
+		Count int `db:"count"`
+	}
+	query = fmt.Sprintf(`
+    SELECT EXTRACT(HOUR FROM %s)::INT AS hour, COUNT(1) AS count
+    FROM reports WHERE %s
+    GROUP BY 1`, localTime, where)
+	err = p.connection.SelectContext(ctx, &hourRows, p.connection.Rebind(query),
+		append([]interface{}{q.Location.String()}, args...)...)
+	if err != nil {
+		return domain.ReportStats{}, fmt.Errorf("error counting reports by hour of day: %w", err)
+	}
+	for _, row := range hourRows {
+		stats.ByHourOfDay[row.Hour] = row.Count
+	}
+
+	var cellRows []struct {
+		CellY int64 `db:"cell_y"`
+		CellX int64 `db:"cell_x"`
+		Count int   `db:"count"`
+	}
+	query = `
+    SELECT FLOOR(lat / ?)::BIGINT AS cell_y, FLOOR(lng / ?)::BIGINT AS cell_x, COUNT(1) AS count
+    FROM reports WHERE lat IS NOT NULL AND ` + where + `
+    GROUP BY 1, 2
+    ORDER BY count DESC, 1, 2`
+	err = p.connection.SelectContext(ctx, &cellRows, p.connection.Rebind(query),
+		append([]interface{}{q.CellSizeInDegrees, q.CellSizeInDegrees}, args...)...)
+	if err != nil {
+		return domain.ReportStats{}, fmt.Errorf("error counting reports by cell: %w", err)
+	}
+	for _, row := range cellRows {
+		stats.ByCell = append(stats.ByCell, domain.CellCount{
+			Latitude:  (float64(row.CellY) + 0.5) * q.CellSizeInDegrees,
+			Longitude: (float64(row.CellX) + 0.5) * q.CellSizeInDegrees,
+			Count:     row.Count,
+		})
+	}
+	return stats, nil
+}
+
+func reportStatsConditions(q infra.ReportStatsQuery, from, to time.Time) ([]string, []interface{}) {
+	conditions := []string{
+		"deleted_at IS NULL", "hidden_at IS NULL", "duplicate_of IS NULL", "status <> 'false_alarm'", "created_at >= ?", "created_at < ?",
+	}
+	args := []interface{}{from, to}
+	if q.Box != nil {
+		conditions = append(conditions, "lat BETWEEN ? AND ?", "lng BETWEEN ? AND ?")
+		args = append(args, q.Box.MinLatitude, q.Box.MaxLatitude, q.Box.MinLongitude, q.Box.MaxLongitude)
+	}
+	if len(q.IncidentTypes) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(q.IncidentTypes)), ", ")
+		conditions = append(conditions, "incident_type IN ("+placeholders+")")
+		for _, incidentType := range q.IncidentTypes {
+			args = append(args, incidentType)
+		}
+	}
+	return conditions, args
+}
+
+func (p *PostgresReportRepository) GetReportsWithoutAdminArea(ctx context.Context, after uuid.UUID, limit int) ([]domain.Report, error) {
+	var reports []SqlxReport
+	err := p.connection.SelectContext(ctx, &reports, `
+    SELECT * FROM reports
+    WHERE state_code IS NULL AND lat IS NOT NULL AND id > $1
+    ORDER BY id
+    LIMIT $2`, after, limit)
+	if err != nil {
+		return []domain.Report{}, fmt.Errorf("error getting reports without admin area: %w", err)
+	}
+
+	result := []domain.Report{}
+	for _, report := range reports {
+		result = append(result, toReport(report))
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) GetReportsWithLocation(ctx context.Context, after uuid.UUID, limit int) ([]domain.Report, error) {
+	var reports []SqlxReport
+	err := p.connection.SelectContext(ctx, &reports, `
+    SELECT * FROM reports
+    WHERE lat IS NOT NULL AND id > $1
+    ORDER BY id
+    LIMIT $2`, after, limit)
+	if err != nil {
+		return []domain.Report{}, fmt.Errorf("error getting reports with location: %w", err)
+	}
+
+	result := []domain.Report{}
+	for _, report := range reports {
+		result = append(result, toReport(report))
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) SetReportAdminArea(ctx context.Context, reportId uuid.UUID, stateCode, lgaCode string) error {
+	result, err := p.connection.ExecContext(ctx,
+		"UPDATE reports SET state_code = $1, lga_code = $2 WHERE id = $3",
+		sql.NullString{String: stateCode, Valid: stateCode != ""},
+		sql.NullString{String: lgaCode, Valid: lgaCode != ""},
+		reportId)
+	if err != nil {
+		return fmt.Errorf("error setting report admin area: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return infra.ErrReportNotFound
+	}
+	return nil
+}
+
+// addContentFindings also puts the report in the moderation queue when a
+// finding flags it.
+func addContentFindings(ctx context.Context, tx *sqlx.Tx, findings []domain.ContentFinding) error {
+	const query = `
+    INSERT INTO report_content_findings
+      (id, report_id, rule, language, action, matched_text, created_at)
+    VALUES
+    (:id, :report_id, :rule, :language, :action, :matched_text, :created_at)
+  `
+	flagged := map[uuid.UUID]domain.ContentFinding{}
+	for _, finding := range findings {
+		if _, err := tx.NamedExecContext(ctx, query, toSqlxContentFinding(finding)); err != nil {
+			return fmt.Errorf("error adding content findings: %w", err)
+		}
+		if _, ok := flagged[finding.ReportID]; !ok && finding.Action == domain.ContentActionFlag {
+			flagged[finding.ReportID] = finding
+		}
+	}
+
+	for reportId, finding := range flagged {
+		_, err := tx.ExecContext(ctx, `
+      INSERT INTO report_flags (id, report_id, user_id, reason, note, created_at)
+      VALUES ($1, $2, NULL, $3, $4, $5)`,
+			uuid.New(), reportId, domain.FlagReasonAbusive, "flagged by the "+finding.Rule+" filter", finding.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("error flagging report content: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *PostgresReportRepository) GetContentFindings(ctx context.Context, reportId uuid.UUID) ([]domain.ContentFinding, error) {
+	var findings []SqlxContentFinding
+	err := p.connection.SelectContext(ctx, &findings,
+		"SELECT * FROM report_content_findings WHERE report_id = $1 ORDER BY created_at, rule", reportId)
+	if err != nil {
+		return []domain.ContentFinding{}, fmt.Errorf("error getting content findings: %w", err)
+	}
+
+	result := []domain.ContentFinding{}
+	for _, finding := range findings {
+		result = append(result, toContentFinding(finding))
+	}
+	return result, nil
+}
+
+func (p *PostgresReportRepository) Count(ctx context.Context, q infra.ReportFeedQuery) (int, error) {
+	conditions, args := reportFeedConditions(q)
+	query := `
+	SELECT
+		count(1)
+	FROM
+		reports
+	WHERE ` + strings.Join(conditions, " AND ")
+
+	var count int
+	if err := p.connection.Get(&count, p.connection.Rebind(query), args...); err != nil {
 		return 0, fmt.Errorf("failed to get the count from the postgres database: %w", err)
 	}
 	return count, nil
@@ -125,37 +1190,255 @@ func (p *PostgresReportRepository) Ping(ctx context.Context) error {
 }
 
 type SqlxReport struct {
-	ID           uuid.UUID `db:"id"`
-	OwnerID      uuid.UUID `db:"owner_id"`
-	IncidentType string    `db:"incident_type"`
-	Longitude    string    `db:"longitude"`
-	Latitude     string    `db:"latitude"`
-	Description  string    `db:"description"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID                 uuid.UUID       `db:"id"`
+	OwnerID            uuid.NullUUID   `db:"owner_id"`
+	IncidentType       string          `db:"incident_type"`
+	Longitude          string          `db:"longitude"`
+	Latitude           string          `db:"latitude"`
+	Lat                sql.NullFloat64 `db:"lat"`
+	Lng                sql.NullFloat64 `db:"lng"`
+	Description        string          `db:"description"`
+	Status             string          `db:"status"`
+	ConfirmationCount  int             `db:"confirmation_count"`
+	DisputeCount       int             `db:"dispute_count"`
+	CredibilityScore   float64         `db:"credibility_score"`
+	CreatedAt          time.Time       `db:"created_at"`
+	UpdatedAt          time.Time       `db:"updated_at"`
+	DeletedAt          sql.NullTime    `db:"deleted_at"`
+	IsAnonymous        bool            `db:"is_anonymous"`
+	DuplicateOf        uuid.NullUUID   `db:"duplicate_of"`
+	CorroborationCount int             `db:"corroboration_count"`
+	StateCode          sql.NullString  `db:"state_code"`
+	LGACode            sql.NullString  `db:"lga_code"`
+	HiddenAt           sql.NullTime    `db:"hidden_at"`
+	ClientID           uuid.NullUUID   `db:"client_id"`
+	OccurredAt         sql.NullTime    `db:"occurred_at"`
+	// SearchVector is generated by the database and only read back
+	SearchVector sql.NullString `db:"search_vector"`
 }
 
+type SqlxNearbyReport struct {
+	SqlxReport
+	Distance float64 `db:"distance"`
+}
+
+type SqlxReportSearchResult struct {
+	SqlxReport
+	Rank    float64 `db:"rank"`
+	Snippet string  `db:"snippet"`
+}
+
+// haversineDistanceSql expects the reference latitude, latitude and longitude
+// as its first three bind parameters.
+const haversineDistanceSql = `(2 * 6371000 * ASIN(SQRT(
+        POWER(SIN(RADIANS(lat - ?) / 2), 2) +
+        COS(RADIANS(?)) * COS(RADIANS(lat)) * POWER(SIN(RADIANS(lng - ?) / 2), 2)
+      )))`
+
 func toReport(r SqlxReport) domain.Report {
 	return domain.Report{
-		ID:           r.ID,
-		OwnerID:      r.OwnerID,
-		IncidentType: r.IncidentType,
-		Longitude:    r.Longitude,
-		Latitude:     r.Latitude,
-		Description:  r.Description,
-		CreatedAt:    r.CreatedAt,
-		UpdatedAt:    r.UpdatedAt,
+		ID:                 r.ID,
+		OwnerID:            r.OwnerID.UUID,
+		IncidentType:       r.IncidentType,
+		Longitude:          r.Longitude,
+		Latitude:           r.Latitude,
+		Lat:                r.Lat.Float64,
+		Lng:                r.Lng.Float64,
+		Description:        r.Description,
+		Status:             r.Status,
+		ConfirmationCount:  r.ConfirmationCount,
+		DisputeCount:       r.DisputeCount,
+		CredibilityScore:   r.CredibilityScore,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+		DeletedAt:          r.DeletedAt.Time,
+		IsAnonymous:        r.IsAnonymous,
+		DuplicateOf:        r.DuplicateOf.UUID,
+		CorroborationCount: r.CorroborationCount,
+		StateCode:          r.StateCode.String,
+		LGACode:            r.LGACode.String,
+		HiddenAt:           r.HiddenAt.Time,
+		ClientID:           r.ClientID.UUID,
+		OccurredAt:         r.OccurredAt.Time,
 	}
 }
 
 func toSqlxReport(r domain.Report) SqlxReport {
 	return SqlxReport{
-		ID:           r.ID,
-		IncidentType: r.IncidentType,
-		Longitude:    r.Longitude,
-		Latitude:     r.Latitude,
-		Description:  r.Description,
-		CreatedAt:    r.CreatedAt,
-		UpdatedAt:    r.UpdatedAt,
+		ID:                 r.ID,
+		OwnerID:            uuid.NullUUID{UUID: r.OwnerID, Valid: r.OwnerID != uuid.Nil},
+		IncidentType:       r.IncidentType,
+		Longitude:          r.Longitude,
+		Latitude:           r.Latitude,
+		Lat:                sql.NullFloat64{Float64: r.Lat, Valid: true},
+		Lng:                sql.NullFloat64{Float64: r.Lng, Valid: true},
+		Description:        r.Description,
+		Status:             r.Status,
+		ConfirmationCount:  r.ConfirmationCount,
+		DisputeCount:       r.DisputeCount,
+		CredibilityScore:   r.CredibilityScore,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+		DeletedAt:          sql.NullTime{Time: r.DeletedAt, Valid: !r.DeletedAt.IsZero()},
+		IsAnonymous:        r.IsAnonymous,
+		DuplicateOf:        uuid.NullUUID{UUID: r.DuplicateOf, Valid: r.DuplicateOf != uuid.Nil},
+		CorroborationCount: r.CorroborationCount,
+		StateCode:          sql.NullString{String: r.StateCode, Valid: r.StateCode != ""},
+		LGACode:            sql.NullString{String: r.LGACode, Valid: r.LGACode != ""},
+		HiddenAt:           sql.NullTime{Time: r.HiddenAt, Valid: !r.HiddenAt.IsZero()},
+		ClientID:           uuid.NullUUID{UUID: r.ClientID, Valid: r.ClientID != uuid.Nil},
+		OccurredAt:         sql.NullTime{Time: r.OccurredAt, Valid: !r.OccurredAt.IsZero()},
+	}
+}
+
+type SqlxReportStatusChange struct {
+	ID         uuid.UUID     `db:"id"`
+	ReportID   uuid.UUID     `db:"report_id"`
+	FromStatus string        `db:"from_status"`
+	ToStatus   string        `db:"to_status"`
+	ChangedBy  uuid.NullUUID `db:"changed_by"`
+	Note       string        `db:"note"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
+
+func toReportStatusChange(c SqlxReportStatusChange) domain.ReportStatusChange {
+	return domain.ReportStatusChange{
+		ID:         c.ID,
+		ReportID:   c.ReportID,
+		FromStatus: c.FromStatus,
+		ToStatus:   c.ToStatus,
+		ChangedBy:  c.ChangedBy.UUID,
+		Note:       c.Note,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func toSqlxReportStatusChange(c domain.ReportStatusChange) SqlxReportStatusChange {
+	return SqlxReportStatusChange{
+		ID:         c.ID,
+		ReportID:   c.ReportID,
+		FromStatus: c.FromStatus,
+		ToStatus:   c.ToStatus,
+		ChangedBy:  uuid.NullUUID{UUID: c.ChangedBy, Valid: c.ChangedBy != uuid.Nil},
+		Note:       c.Note,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+type SqlxReportVote struct {
+	ReportID  uuid.UUID `db:"report_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Vote      string    `db:"vote"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toSqlxReportVote(v domain.ReportVote) SqlxReportVote {
+	return SqlxReportVote{
+		ReportID:  v.ReportID,
+		UserID:    v.UserID,
+		Vote:      v.Vote,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+type SqlxReportMedia struct {
+	ReportID  uuid.UUID `db:"report_id"`
+	FileID    uuid.UUID `db:"file_id"`
+	OwnerID   uuid.UUID `db:"owner_id"`
+	Url       string    `db:"url"`
+	MimeType  string    `db:"mime_type"`
+	Position  int       `db:"position"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toReportMedia(m SqlxReportMedia) domain.ReportMedia {
+	return domain.ReportMedia{
+		ReportID:  m.ReportID,
+		FileID:    m.FileID,
+		OwnerID:   m.OwnerID,
+		Url:       m.Url,
+		MimeType:  m.MimeType,
+		Position:  m.Position,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func toSqlxReportMedia(m domain.ReportMedia) SqlxReportMedia {
+	return SqlxReportMedia{
+		ReportID:  m.ReportID,
+		FileID:    m.FileID,
+		OwnerID:   m.OwnerID,
+		Url:       m.Url,
+		MimeType:  m.MimeType,
+		Position:  m.Position,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+type SqlxReportLink struct {
+	ID                uuid.UUID     `db:"id"`
+	ReportID          uuid.UUID     `db:"report_id"`
+	PrimaryID         uuid.NullUUID `db:"primary_id"`
+	PreviousPrimaryID uuid.NullUUID `db:"previous_primary_id"`
+	Action            string        `db:"action"`
+	ActorID           uuid.NullUUID `db:"actor_id"`
+	CreatedAt         time.Time     `db:"created_at"`
+}
+
+func toReportLink(l SqlxReportLink) domain.ReportLink {
+	return domain.ReportLink{
+		ID:                l.ID,
+		ReportID:          l.ReportID,
+		PrimaryID:         l.PrimaryID.UUID,
+		PreviousPrimaryID: l.PreviousPrimaryID.UUID,
+		Action:            l.Action,
+		ActorID:           l.ActorID.UUID,
+		CreatedAt:         l.CreatedAt,
+	}
+}
+
+func toSqlxReportLink(l domain.ReportLink) SqlxReportLink {
+	return SqlxReportLink{
+		ID:                l.ID,
+		ReportID:          l.ReportID,
+		PrimaryID:         uuid.NullUUID{UUID: l.PrimaryID, Valid: l.PrimaryID != uuid.Nil},
+		PreviousPrimaryID: uuid.NullUUID{UUID: l.PreviousPrimaryID, Valid: l.PreviousPrimaryID != uuid.Nil},
+		Action:            l.Action,
+		ActorID:           uuid.NullUUID{UUID: l.ActorID, Valid: l.ActorID != uuid.Nil},
+		CreatedAt:         l.CreatedAt,
+	}
+}
+
+type SqlxContentFinding struct {
+	ID        uuid.UUID `db:"id"`
+	ReportID  uuid.UUID `db:"report_id"`
+	Rule      string    `db:"rule"`
+	Language  string    `db:"language"`
+	Action    string    `db:"action"`
+	Match     string    `db:"matched_text"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toContentFinding(f SqlxContentFinding) domain.ContentFinding {
+	return domain.ContentFinding{
+		ID:        f.ID,
+		ReportID:  f.ReportID,
+		Rule:      f.Rule,
+		Language:  f.Language,
+		Action:    f.Action,
+		Match:     f.Match,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func toSqlxContentFinding(f domain.ContentFinding) SqlxContentFinding {
+	return SqlxContentFinding{
+		ID:        f.ID,
+		ReportID:  f.ReportID,
+		Rule:      f.Rule,
+		Language:  f.Language,
+		Action:    f.Action,
+		Match:     f.Match,
+		CreatedAt: f.CreatedAt,
 	}
 }
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"time"
@@ -27,9 +28,9 @@ func NewPostgresUserRepo(ctx context.Context, connection *sqlx.DB) (*PostgresUse
 func (p *PostgresUserRepository) CreateUser(ctx context.Context, user domain.User) error {
 	const query = `
     INSERT INTO users
-      (id, first_name, last_name, user_name, email, password, avatar_url, location, phone, created_at, updated_at) 
+      (id, first_name, last_name, user_name, email, password, avatar_url, location, phone, role, hide_reports, created_at, updated_at) 
     VALUES 
-    (:id, :first_name, :last_name, :user_name, :email, :password, :avatar_url, :location, :phone, :created_at, :updated_at)
+    (:id, :first_name, :last_name, :user_name, :email, :password, :avatar_url, :location, :phone, :role, :hide_reports, :created_at, :updated_at)
   `
 
 	_, err := p.connection.NamedExec(query, toSqlxUser(user))
@@ -52,6 +53,8 @@ func (p *PostgresUserRepository) UpdateUser(ctx context.Context, user domain.Use
 		"password" = :password,
 		"location" = :location,
 		"phone" = :phone,
+		"role" = :role,
+		"hide_reports" = :hide_reports,
 		"created_at" = :created_at,
 		"updated_at" = :updated_at
   WHERE 
@@ -112,47 +115,59 @@ func (p *PostgresUserRepository) Ping(ctx context.Context) error {
 }
 
 type SqlxUser struct {
-	ID        uuid.UUID `db:"id"`
-	Email     string    `db:"email"`
-	AvatarUrl string    `db:"avatar_url"`
-	FirstName string    `db:"first_name"`
-	LastName  string    `db:"last_name"`
-	UserName  string    `db:"user_name"`
-	Password  string    `db:"password"`
-	Location  string    `db:"location"`
-	Phone     string    `db:"phone"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID             uuid.UUID    `db:"id"`
+	Email          string       `db:"email"`
+	AvatarUrl      string       `db:"avatar_url"`
+	FirstName      string       `db:"first_name"`
+	LastName       string       `db:"last_name"`
+	UserName       string       `db:"user_name"`
+	Password       string       `db:"password"`
+	Location       string       `db:"location"`
+	Phone          string       `db:"phone"`
+	Role           string       `db:"role"`
+	HideReports    bool         `db:"hide_reports"`
+	WarningCount   int          `db:"warning_count"`
+	SuspendedUntil sql.NullTime `db:"suspended_until"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
 }
 
 func toUser(u SqlxUser) domain.User {
 	return domain.User{
-		ID:        u.ID,
-		AvatarUrl: u.AvatarUrl,
-		Email:     u.Email,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		UserName:  u.UserName,
-		Password:  u.Password,
-		Location:  u.Location,
-		Phone:     u.Phone,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:             u.ID,
+		AvatarUrl:      u.AvatarUrl,
+		Email:          u.Email,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		UserName:       u.UserName,
+		Password:       u.Password,
+		Location:       u.Location,
+		Phone:          u.Phone,
+		Role:           u.Role,
+		HideReports:    u.HideReports,
+		WarningCount:   u.WarningCount,
+		SuspendedUntil: u.SuspendedUntil.Time,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
 	}
 }
 
 func toSqlxUser(u domain.User) SqlxUser {
 	return SqlxUser{
-		ID:        u.ID,
-		AvatarUrl: u.AvatarUrl,
-		Email:     u.Email,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		UserName:  u.UserName,
-		Password:  u.Password,
-		Location:  u.Location,
-		Phone:     u.Phone,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:             u.ID,
+		AvatarUrl:      u.AvatarUrl,
+		Email:          u.Email,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		UserName:       u.UserName,
+		Password:       u.Password,
+		Location:       u.Location,
+		Phone:          u.Phone,
+		Role:           u.Role,
+		HideReports:    u.HideReports,
+		WarningCount:   u.WarningCount,
+		SuspendedUntil: sql.NullTime{Time: u.SuspendedUntil, Valid: !u.SuspendedUntil.IsZero()},
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
 	}
 }
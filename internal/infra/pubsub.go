@@ -0,0 +1,10 @@
+package infra
+
+import "context"
+
+type PubSub interface {
+	Publish(ctx context.Context, channel, message string) error
+	// Subscribe delivers every message published on channel until ctx is
+	// done, the returned channel is closed afterwards.
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+}
@@ -2,12 +2,14 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/olad5/caution-companion/config"
+	"github.com/olad5/caution-companion/internal/infra"
 )
 
 type RedisCache struct {
@@ -40,8 +42,19 @@ func (r *RedisCache) SetOne(ctx context.Context, key, value string, ttl time.Dur
 	return nil
 }
 
+func (r *RedisCache) SetOneIfNotExists(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	ok, err := r.Client.SetNX(ctx, r.prefixKeyWithAppName(key), value, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("Error setting value in cache: %w", err)
+	}
+	return ok, nil
+}
+
 func (r *RedisCache) GetOne(ctx context.Context, key string) (string, error) {
 	result, err := r.Client.Get(ctx, r.prefixKeyWithAppName(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", infra.ErrCacheMiss
+	}
 	if err != nil {
 		return "", fmt.Errorf("Error getting value from cache: %w", err)
 	}
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+func (r *RedisCache) Publish(ctx context.Context, channel, message string) error {
+	err := r.Client.Publish(ctx, r.prefixKeyWithAppName(channel), message).Err()
+	if err != nil {
+		return fmt.Errorf("Error publishing message: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisCache) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	pubsub := r.Client.Subscribe(ctx, r.prefixKeyWithAppName(channel))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("Error subscribing to channel: %w", err)
+	}
+
+	messages := make(chan string)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		incoming := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-incoming:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- message.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages, nil
+}
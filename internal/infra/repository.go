@@ -4,14 +4,31 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
 )
 
 var (
 	ErrUserNotFound   = errors.New("user not found")
 	ErrReportNotFound = errors.New("report not found")
+
+	ErrReportStatusConflict = errors.New("report status was changed by another request")
+	ErrReportVoteExists     = errors.New("you have already voted on this report")
+	ErrReportVoteNotFound   = errors.New("vote not found")
+
+	ErrIncidentTypeNotFound = errors.New("incident type not found")
+	ErrIncidentTypeExists   = errors.New("an incident type with this slug already exists")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrFileNotFound         = errors.New("file not found")
+
+	ErrAlertSubscriptionNotFound = errors.New("alert subscription not found")
+	ErrDeviceNotFound            = errors.New("device not found")
+
+	ErrReportAlreadyFlagged = errors.New("you have already flagged this report")
+	ErrReportClientIDExists = errors.New("a report with this client_id already exists")
 )
 
 type UserRepository interface {
@@ -24,10 +41,176 @@ type UserRepository interface {
 }
 
 type ReportRepository interface {
-	CreateReport(ctx context.Context, report domain.Report) error
-	GetReportsByUserId(ctx context.Context, userId uuid.UUID, pageNumber, rowsPerPage int) ([]domain.Report, error)
-	GetLatestReports(ctx context.Context, pageNumber, rowsPerPage int) ([]domain.Report, error)
+	// CreateReport and CreateAnonymousReport store the report, its media and
+	// its content findings together, findings that flag the report put it in
+	// the moderation queue. They fail with ErrReportClientIDExists when a
+	// report already has the client id
+	CreateReport(ctx context.Context, report domain.Report, findings []domain.ContentFinding) error
+	GetReportsByUserId(ctx context.Context, userId uuid.UUID, status string, pageNumber, rowsPerPage int) ([]domain.Report, error)
+	CountReportsByUserId(ctx context.Context, userId uuid.UUID, status string) (int, error)
+	GetReportFeed(ctx context.Context, query ReportFeedQuery, sortBy string, after ReportFeedCursor, limit int) ([]domain.Report, error)
+	Count(ctx context.Context, query ReportFeedQuery) (int, error)
 	GetReportByReportId(ctx context.Context, reportId uuid.UUID) (domain.Report, error)
+	// GetReportByClientId only finds reports made by the owner, client ids
+	// are unique per owner
+	GetReportByClientId(ctx context.Context, ownerId, clientId uuid.UUID) (domain.Report, error)
+	// UpdateReport stores the content findings of the new text with it
+	UpdateReport(ctx context.Context, report domain.Report, findings []domain.ContentFinding) error
+	GetNearbyReports(ctx context.Context, query NearbyReportsQuery, pageNumber, rowsPerPage int) ([]domain.NearbyReport, error)
+	SearchReports(ctx context.Context, query SearchReportsQuery, pageNumber, rowsPerPage int) ([]domain.ReportSearchResult, error)
+	CountReportsInBoundingBox(ctx context.Context, box geo.BoundingBox) (int, error)
+	GetReportsInBoundingBox(ctx context.Context, box geo.BoundingBox, limit int) ([]domain.Report, error)
+	GetReportClustersInBoundingBox(ctx context.Context, box geo.BoundingBox, cellSizeInDegrees float64) ([]domain.ReportCluster, error)
+	UpdateReportStatus(ctx context.Context, change domain.ReportStatusChange) error
+	GetReportStatusHistory(ctx context.Context, reportId uuid.UUID) ([]domain.ReportStatusChange, error)
+	CreateReportVote(ctx context.Context, vote domain.ReportVote) error
+	DeleteReportVote(ctx context.Context, reportId, userId uuid.UUID) error
+	GetUserVotes(ctx context.Context, userId uuid.UUID, reportIds []uuid.UUID) (map[uuid.UUID]string, error)
+	AddReportMedia(ctx context.Context, media []domain.ReportMedia) error
+	GetReportMedia(ctx context.Context, reportIds []uuid.UUID) ([]domain.ReportMedia, error)
+	LinkReport(ctx context.Context, link domain.ReportLink) error
+	GetReportLinks(ctx context.Context, reportId uuid.UUID) ([]domain.ReportLink, error)
+	GetCorroborations(ctx context.Context, primaryId uuid.UUID) ([]domain.Report, error)
+	CreateAnonymousReport(ctx context.Context, report domain.Report, ownerId uuid.UUID, findings []domain.ContentFinding) error
+	// GetAnonymousReportOwner must only be used for abuse handling and to
+	// let owners manage their own anonymous reports
+	GetAnonymousReportOwner(ctx context.Context, reportId uuid.UUID) (uuid.UUID, error)
+	CountAnonymousReportsByOwnerSince(ctx context.Context, ownerId uuid.UUID, since time.Time) (int, error)
+	ExpireReports(ctx context.Context, now time.Time, limit int) ([]domain.Report, error)
+	GetReportStats(ctx context.Context, query ReportStatsQuery) (domain.ReportStats, error)
+	// GetReportsWithoutAdminArea pages through the reports that have no state
+	// by id, starting after the given id
+	GetReportsWithoutAdminArea(ctx context.Context, after uuid.UUID, limit int) ([]domain.Report, error)
+	// GetReportsWithLocation pages through every report with coordinates by
+	// id, starting after the given id
+	GetReportsWithLocation(ctx context.Context, after uuid.UUID, limit int) ([]domain.Report, error)
+	SetReportAdminArea(ctx context.Context, reportId uuid.UUID, stateCode, lgaCode string) error
+	GetContentFindings(ctx context.Context, reportId uuid.UUID) ([]domain.ContentFinding, error)
+}
+
+const (
+	ReportSortLatest      = "latest"
+	ReportSortOldest      = "oldest"
+	ReportSortCredibility = "credibility"
+)
+
+// ReportFeedQuery filters are only applied when set. Live reports are listed
+// unless Status asks for expired ones.
+type ReportFeedQuery struct {
+	IncidentTypes []string
+	Status        string
+	OwnerID       uuid.UUID
+	StateCode     string
+	LGACode       string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// ReportFeedCursor is the sort key of the last report of the previous page.
+// A zero cursor starts from the beginning of the feed.
+type ReportFeedCursor struct {
+	CreatedAt        time.Time
+	CredibilityScore float64
+	ID               uuid.UUID
+}
+
+func (c ReportFeedCursor) IsZero() bool {
+	return c.ID == uuid.Nil
+}
+
+// ReportStatsQuery counts the primary reports made in [From, To) that were
+// not found to be false alarms.
+type ReportStatsQuery struct {
+	// Box is nil to count reports everywhere
+	Box               *geo.BoundingBox
+	IncidentTypes     []string
+	From              time.Time
+	To                time.Time
+	Location          *time.Location
+	CellSizeInDegrees float64
+}
+
+type NearbyReportsQuery struct {
+	Latitude       float64
+	Longitude      float64
+	RadiusInMeters float64
+	IncidentType   string
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+}
+
+// SearchReportsQuery.Text uses web search syntax, so quoted text is matched
+// as a phrase.
+type SearchReportsQuery struct {
+	Text          string
+	IncidentType  string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+type IncidentTypeRepository interface {
+	CreateIncidentType(ctx context.Context, incidentType domain.IncidentType) error
+	UpdateIncidentType(ctx context.Context, incidentType domain.IncidentType) error
+	GetIncidentTypeBySlug(ctx context.Context, slug string) (domain.IncidentType, error)
+	GetIncidentTypes(ctx context.Context, includeInactive bool) ([]domain.IncidentType, error)
+}
+
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment domain.Comment) error
+	UpdateComment(ctx context.Context, comment domain.Comment) error
+	DeleteComment(ctx context.Context, commentId uuid.UUID) error
+	GetCommentByCommentId(ctx context.Context, commentId uuid.UUID) (domain.Comment, error)
+	GetCommentsByReportId(ctx context.Context, reportId uuid.UUID, pageNumber, rowsPerPage int) ([]domain.Comment, error)
+	GetRepliesByParentIds(ctx context.Context, parentIds []uuid.UUID) ([]domain.Comment, error)
+}
+
+type FileRepository interface {
+	CreateFile(ctx context.Context, file domain.File) error
+	GetFilesByFileIds(ctx context.Context, fileIds []uuid.UUID) ([]domain.File, error)
+}
+
+type AlertSubscriptionRepository interface {
+	CreateAlertSubscription(ctx context.Context, subscription domain.AlertSubscription) error
+	UpdateAlertSubscription(ctx context.Context, subscription domain.AlertSubscription) error
+	DeleteAlertSubscription(ctx context.Context, subscriptionId uuid.UUID) error
+	GetAlertSubscriptionById(ctx context.Context, subscriptionId uuid.UUID) (domain.AlertSubscription, error)
+	GetAlertSubscriptionsByUserId(ctx context.Context, userId uuid.UUID) ([]domain.AlertSubscription, error)
+	CountAlertSubscriptionsByUserId(ctx context.Context, userId uuid.UUID) (int, error)
+	// GetAlertSubscriptionsNearPoint returns every subscription whose bounding
+	// box contains the point, callers still have to check the exact shape.
+	GetAlertSubscriptionsNearPoint(ctx context.Context, lat, lng float64) ([]domain.AlertSubscription, error)
+}
+
+type DeviceRepository interface {
+	// SaveDevice registers the device, a device token that is already
+	// registered is moved to the new owner and its keys are replaced.
+	SaveDevice(ctx context.Context, device domain.Device) (domain.Device, error)
+	DeleteDevice(ctx context.Context, deviceId uuid.UUID) error
+	GetDeviceById(ctx context.Context, deviceId uuid.UUID) (domain.Device, error)
+	GetDevicesByUserId(ctx context.Context, userId uuid.UUID) ([]domain.Device, error)
+	CountDevicesByUserId(ctx context.Context, userId uuid.UUID) (int, error)
+}
+
+type NotificationPreferenceRepository interface {
+	SaveNotificationPreferences(ctx context.Context, preferences []domain.NotificationPreference) error
+	GetNotificationPreferences(ctx context.Context, userId uuid.UUID) ([]domain.NotificationPreference, error)
+}
+
+type ModerationRepository interface {
+	// CreateReportFlag fails with ErrReportAlreadyFlagged when the user still
+	// has a pending flag on the report
+	CreateReportFlag(ctx context.Context, flag domain.ReportFlag) error
+	// GetModerationQueue lists the reports with pending flags, the most
+	// flagged first and then the most recently flagged
+	GetModerationQueue(ctx context.Context, pageNumber, rowsPerPage int) ([]domain.FlaggedReport, error)
+	CountModerationQueue(ctx context.Context) (int, error)
+	// ApplyReportAction hides, restores or deletes the report, resolves its
+	// pending flags and records the action in one transaction
+	ApplyReportAction(ctx context.Context, action domain.ModerationAction) error
+	// ApplyUserAction warns or suspends the user and records the action in
+	// one transaction
+	ApplyUserAction(ctx context.Context, action domain.ModerationAction) error
+	GetModerationActionsByReportId(ctx context.Context, reportId uuid.UUID) ([]domain.ModerationAction, error)
 }
 
 type FileStore interface {
@@ -0,0 +1,348 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/olad5/caution-companion/config"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize is the only record size used, every payload fits in a
+	// single record
+	recordSize = 4096
+	// MaxPayloadSize is what is left of the 4096 bytes push services accept
+	// once the header, the padding delimiter and the tag are added
+	MaxPayloadSize = recordSize - headerSize - 1 - 16
+	headerSize     = 16 + 4 + 1 + 65
+	ttlInSeconds   = 24 * 60 * 60
+	vapidTokenTTL  = 12 * time.Hour
+)
+
+var (
+	ErrPayloadTooLarge  = fmt.Errorf("web push payload is larger than %d bytes", MaxPayloadSize)
+	ErrNonPublicAddress = errors.New("web push endpoints must resolve to public addresses")
+)
+
+// WebPush delivers notifications to browsers using the Web Push protocol
+// with VAPID authentication. Payloads are encrypted with aes128gcm as
+// described in RFC 8291.
+type WebPush struct {
+	client     *http.Client
+	deviceRepo infra.DeviceRepository
+	privateKey *ecdsa.PrivateKey
+	publicKey  []byte
+	subject    string
+}
+
+func New(ctx context.Context, cfg *config.Configurations, deviceRepo infra.DeviceRepository) (*WebPush, error) {
+	if deviceRepo == nil {
+		return &WebPush{}, errors.New("WebPush failed to initialize, deviceRepo is nil")
+	}
+
+	privateKey, publicKey, err := decodeVapidPrivateKey(cfg.VapidPrivateKey)
+	if err != nil {
+		return &WebPush{}, fmt.Errorf("WebPush failed to initialize: %w", err)
+	}
+
+	return &WebPush{
+		client:     newClient(),
+		deviceRepo: deviceRepo,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		subject:    "mailto:" + cfg.SenderEmail,
+	}, nil
+}
+
+// newClient only connects to public addresses and does not follow
+// redirects, endpoints come from users so they could otherwise point at
+// internal services.
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: rejectNonPublicAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// the proxy would be dialed instead of the endpoint
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// rejectNonPublicAddress runs after the host is resolved, so it also catches
+// names that resolve to internal addresses.
+func rejectNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(ip) {
+		return fmt.Errorf("%w, %s is not", ErrNonPublicAddress, ip)
+	}
+	return nil
+}
+
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+func isPublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *WebPush) Channel() string {
+	return domain.NotificationChannelPush
+}
+
+// PublicKey is the application server key browsers subscribe with.
+func (w *WebPush) PublicKey() string {
+	return base64.RawURLEncoding.EncodeToString(w.publicKey)
+}
+
+// DerivePublicKey returns the application server key of a VAPID private
+// key without creating a WebPush.
+func DerivePublicKey(vapidPrivateKey string) (string, error) {
+	_, publicKey, err := decodeVapidPrivateKey(vapidPrivateKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(publicKey), nil
+}
+
+func (w *WebPush) Notify(ctx context.Context, recipient infra.NotificationRecipient, notification infra.Notification) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"title": notification.Title,
+		"body":  notification.Body,
+		"data":  notification.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding web push payload: %w", err)
+	}
+	if len(payload) > MaxPayloadSize {
+		return ErrPayloadTooLarge
+	}
+
+	var errs []error
+	for _, device := range recipient.Devices {
+		if device.Platform != domain.DevicePlatformWeb {
+			continue
+		}
+		if err := w.send(ctx, device, payload); err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", device.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *WebPush) send(ctx context.Context, device domain.Device, payload []byte) error {
+	body, err := encrypt(device, payload)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := w.vapidAuthorization(device.Token)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, device.Token, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating web push request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(ttlInSeconds))
+	req.Header.Set("Urgency", "high")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending web push: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// the browser unsubscribed, the endpoint will never work again
+		return w.deviceRepo.DeleteDevice(ctx, device.ID)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("web push rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *WebPush) vapidAuthorization(endpoint string) (string, error) {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid web push endpoint: %w", err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpointUrl.Scheme + "://" + endpointUrl.Host,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": w.subject,
+	})
+	signed, err := token.SignedString(w.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("error signing vapid token: %w", err)
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, w.PublicKey()), nil
+}
+
+// encrypt builds an aes128gcm body holding a single record.
+func encrypt(device domain.Device, payload []byte) ([]byte, error) {
+	userAgentPublicKey, err := decodeBase64(device.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	authSecret, err := decodeBase64(device.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating web push key: %w", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating web push salt: %w", err)
+	}
+	return encryptWithKey(userAgentPublicKey, authSecret, serverKey, salt, payload)
+}
+
+// encryptWithKey is encrypt with the server key and salt chosen by the
+// caller.
+func encryptWithKey(
+	userAgentPublicKey, authSecret []byte, serverKey *ecdh.PrivateKey, salt, payload []byte,
+) ([]byte, error) {
+	userAgentKey, err := ecdh.P256().NewPublicKey(userAgentPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving web push secret: %w", err)
+	}
+	serverPublicKey := serverKey.PublicKey().Bytes()
+
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentPublicKey...)
+	keyInfo = append(keyInfo, serverPublicKey...)
+	inputKey, err := deriveKey(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	contentKey, err := deriveKey(inputKey, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := deriveKey(inputKey, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting web push payload: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting web push payload: %w", err)
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(serverPublicKey)))
+	header = append(header, serverPublicKey...)
+
+	// 0x02 marks the last and only record
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func deriveKey(secret, salt, info []byte, length int) ([]byte, error) {
+	key := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, fmt.Errorf("error deriving web push key: %w", err)
+	}
+	return key, nil
+}
+
+func decodeVapidPrivateKey(encoded string) (*ecdsa.PrivateKey, []byte, error) {
+	raw, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+
+	publicKey := key.PublicKey().Bytes()
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(publicKey[1:33]),
+			Y:     new(big.Int).SetBytes(publicKey[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}, publicKey, nil
+}
+
+// decodeBase64 accepts keys with or without padding, browsers hand them out
+// url encoded but some libraries use the standard alphabet.
+func decodeBase64(encoded string) ([]byte, error) {
+	for _, encoding := range []*base64.Encoding{
+		base64.RawURLEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.StdEncoding,
+	} {
+		if decoded, err := encoding.DecodeString(encoded); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, errors.New("invalid base64")
+}
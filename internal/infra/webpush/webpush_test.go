@@ -0,0 +1,95 @@
+package webpush
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"encoding/base64"
+	"net/netip"
+	"testing"
+)
+
+// The values are the example of RFC 8291 Appendix A.
+const (
+	rfc8291Plaintext          = "When I grow up, I want to be a watermelon"
+	rfc8291ServerPrivateKey   = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfc8291UserAgentPublicKey = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfc8291AuthSecret         = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfc8291Salt               = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfc8291InputKey           = "S4lYMb_L0FxCeq0WhDx813KgSYqU26kOyzWUdsXYyrg"
+	rfc8291ContentKey         = "oIhVW04MRdy2XN9CiKLxTg"
+	rfc8291Nonce              = "4h_95klXJ5E_qnoN"
+	rfc8291Message            = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func TestEncrypt(t *testing.T) {
+	serverKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, rfc8291ServerPrivateKey))
+	if err != nil {
+		t.Fatalf("invalid server key: %v", err)
+	}
+
+	message, err := encryptWithKey(
+		mustDecode(t, rfc8291UserAgentPublicKey),
+		mustDecode(t, rfc8291AuthSecret),
+		serverKey,
+		mustDecode(t, rfc8291Salt),
+		[]byte(rfc8291Plaintext),
+	)
+	if err != nil {
+		t.Fatalf("error encrypting: %v", err)
+	}
+	if got := base64.RawURLEncoding.EncodeToString(message); got != rfc8291Message {
+		t.Errorf("got message: %s expected: %s", got, rfc8291Message)
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	salt := mustDecode(t, rfc8291Salt)
+	inputKey := mustDecode(t, rfc8291InputKey)
+
+	contentKey, err := deriveKey(inputKey, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		t.Fatalf("error deriving content key: %v", err)
+	}
+	if !bytes.Equal(contentKey, mustDecode(t, rfc8291ContentKey)) {
+		t.Errorf("got content key: %x expected: %x", contentKey, mustDecode(t, rfc8291ContentKey))
+	}
+
+	nonce, err := deriveKey(inputKey, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		t.Fatalf("error deriving nonce: %v", err)
+	}
+	if !bytes.Equal(nonce, mustDecode(t, rfc8291Nonce)) {
+		t.Errorf("got nonce: %x expected: %x", nonce, mustDecode(t, rfc8291Nonce))
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for address, expected := range map[string]bool{
+		"142.250.185.10":   true,
+		"2607:f8b0::200e":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if got := isPublicAddr(netip.MustParseAddr(address)); got != expected {
+			t.Errorf("got isPublicAddr(%s): %v expected: %v", address, got, expected)
+		}
+	}
+}
+
+func mustDecode(t *testing.T, encoded string) []byte {
+	t.Helper()
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("invalid test vector %q: %v", encoded, err)
+	}
+	return decoded
+}
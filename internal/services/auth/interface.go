@@ -10,6 +10,16 @@ import (
 type JWTClaims struct {
 	ID    uuid.UUID
 	Email string
+	Role  string
+}
+
+func (j JWTClaims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if j.Role == role {
+			return true
+		}
+	}
+	return false
 }
 
 type ctxKey int
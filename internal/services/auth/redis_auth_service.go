@@ -58,6 +58,7 @@ func (r *RedisAuthService) GenerateAuthTokens(ctx context.Context, user domain.U
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub":   user.ID,
 		"email": user.Email,
+		"role":  user.Role,
 		"exp":   time.Now().Add(AuthSessionTTLInMinutes).Unix(),
 	})
 	accessToken, err := token.SignedString([]byte(r.SecretKey))
@@ -201,6 +202,12 @@ func (r *RedisAuthService) DecodeJWT(ctx context.Context, authHeader string) (JW
 			jwtClaims.Email = userEmail.(string)
 		}
 
+		jwtClaims.Role = domain.RoleUser
+		userRole, ok := claims["role"].(string)
+		if ok && userRole != "" {
+			jwtClaims.Role = userRole
+		}
+
 		return jwtClaims, nil
 	}
 	return JWTClaims{}, ErrInvalidToken
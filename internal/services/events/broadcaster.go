@@ -0,0 +1,201 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+)
+
+const (
+	reportEventsChannel = "report-events"
+	// historySize is how many recent events are kept for clients resuming
+	// with Last-Event-ID
+	historySize          = 256
+	subscriberBufferSize = 32
+)
+
+// Broadcaster fans report events out to the subscribers connected to this
+// server instance. Events are published through pub/sub so that every
+// instance behind the load balancer delivers them, including the publisher.
+type Broadcaster struct {
+	pubsub infra.PubSub
+	logger *zap.Logger
+
+	// active counts subscriptions their consumers have not closed yet
+	active sync.WaitGroup
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	history     []domain.ReportEvent
+	closed      bool
+}
+
+type Subscription struct {
+	// Events is closed when the subscription ends, either because the
+	// broadcaster shut down or because the subscriber fell too far behind.
+	Events <-chan domain.ReportEvent
+
+	events      chan domain.ReportEvent
+	broadcaster *Broadcaster
+	once        sync.Once
+	releaseOnce sync.Once
+}
+
+func NewBroadcaster(pubsub infra.PubSub, logger *zap.Logger) (*Broadcaster, error) {
+	if pubsub == nil {
+		return &Broadcaster{}, errors.New("Broadcaster failed to initialize, pubsub is nil")
+	}
+	if logger == nil {
+		return &Broadcaster{}, errors.New("Broadcaster failed to initialize, logger is nil")
+	}
+	return &Broadcaster{
+		pubsub:      pubsub,
+		logger:      logger,
+		subscribers: map[*Subscription]struct{}{},
+	}, nil
+}
+
+// Start listens for events from every server instance until ctx is done.
+func (b *Broadcaster) Start(ctx context.Context) error {
+	messages, err := b.pubsub.Subscribe(ctx, reportEventsChannel)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for message := range messages {
+			var event domain.ReportEvent
+			if err := json.Unmarshal([]byte(message), &event); err != nil {
+				b.logger.Error("unable to decode report event", zap.Error(err))
+				continue
+			}
+			b.deliver(event)
+		}
+	}()
+	return nil
+}
+
+// Publish never fails the caller, a report has already been saved by the time
+// its event is published.
+func (b *Broadcaster) Publish(ctx context.Context, eventType string, report domain.Report) {
+	event := domain.ReportEvent{
+		ID:        xid.New().String(),
+		Type:      eventType,
+		Report:    report,
+		CreatedAt: time.Now(),
+	}
+
+	message, err := json.Marshal(event)
+	if err != nil {
+		b.logger.Error("unable to encode report event", zap.Error(err))
+		return
+	}
+	if err := b.pubsub.Publish(ctx, reportEventsChannel, string(message)); err != nil {
+		b.logger.Error("unable to publish report event", zap.Error(err))
+	}
+}
+
+// Subscribe returns the events retained after lastEventID along with the
+// subscription. When lastEventID is no longer retained every retained event
+// is returned, so a resuming client may see some events twice but never
+// misses one that is still held.
+func (b *Broadcaster) Subscribe(lastEventID string) (*Subscription, []domain.ReportEvent) {
+	events := make(chan domain.ReportEvent, subscriberBufferSize)
+	subscription := &Subscription{Events: events, events: events, broadcaster: b}
+	b.active.Add(1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		subscription.closeLocked()
+		return subscription, []domain.ReportEvent{}
+	}
+	b.subscribers[subscription] = struct{}{}
+
+	missed := []domain.ReportEvent{}
+	if lastEventID != "" {
+		start := 0
+		for i, event := range b.history {
+			if event.ID == lastEventID {
+				start = i + 1
+				break
+			}
+		}
+		missed = append(missed, b.history[start:]...)
+	}
+	return subscription, missed
+}
+
+// Close ends every subscription so long lived connections do not hold the
+// server open on shutdown.
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscription := range b.subscribers {
+		subscription.closeLocked()
+	}
+}
+
+// Wait blocks until every subscriber has closed its subscription, giving
+// connections ended by Close the chance to say goodbye to their clients.
+func (b *Broadcaster) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.active.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Broadcaster) deliver(event domain.ReportEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.history = append(b.history, event)
+	if len(b.history) > historySize {
+		b.history = b.history[len(b.history)-historySize:]
+	}
+
+	for subscription := range b.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			// slow subscribers are dropped rather than blocking everyone else,
+			// they can reconnect and resume from their last event
+			subscription.closeLocked()
+		}
+	}
+}
+
+// Close must be called by every subscriber once it is done with the
+// subscription.
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	s.closeLocked()
+	s.broadcaster.mu.Unlock()
+
+	s.releaseOnce.Do(s.broadcaster.active.Done)
+}
+
+func (s *Subscription) closeLocked() {
+	s.once.Do(func() {
+		delete(s.broadcaster.subscribers, s)
+		close(s.events)
+	})
+}
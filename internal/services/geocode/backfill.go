@@ -0,0 +1,67 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+const backfillBatch = 500
+
+// BackfillAdminAreas tags the reports made before reports were geocoded, or
+// that were outside every known area when they were made. With retag every
+// report is tagged again, so reports tagged with older boundaries are fixed,
+// and reports now outside every known area lose their tags. It returns how
+// many reports were tagged.
+func BackfillAdminAreas(ctx context.Context, reportRepo infra.ReportRepository, geocoder *Geocoder, retag bool) (int, error) {
+	if reportRepo == nil {
+		return 0, errors.New("BackfillAdminAreas failed, reportRepo is nil")
+	}
+	if geocoder == nil {
+		return 0, errors.New("BackfillAdminAreas failed, geocoder is nil")
+	}
+
+	getReports := reportRepo.GetReportsWithoutAdminArea
+	if retag {
+		getReports = reportRepo.GetReportsWithLocation
+	}
+
+	tagged := 0
+	after := uuid.Nil
+	for {
+		reports, err := getReports(ctx, after, backfillBatch)
+		if err != nil {
+			return tagged, err
+		}
+		for _, report := range reports {
+			updated, err := tagReport(ctx, reportRepo, geocoder, report, retag)
+			if err != nil {
+				return tagged, err
+			}
+			if updated {
+				tagged++
+			}
+		}
+		if len(reports) < backfillBatch {
+			return tagged, nil
+		}
+		after = reports[len(reports)-1].ID
+	}
+}
+
+func tagReport(ctx context.Context, reportRepo infra.ReportRepository, geocoder *Geocoder, report domain.Report, retag bool) (bool, error) {
+	stateCode, lgaCode := geocoder.Locate(report.Lat, report.Lng)
+	if stateCode == report.StateCode && lgaCode == report.LGACode {
+		return false, nil
+	}
+	if stateCode == "" && !retag {
+		return false, nil
+	}
+	if err := reportRepo.SetReportAdminArea(ctx, report.ID, stateCode, lgaCode); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,190 @@
+// Package geocode tags coordinates with the administrative areas they are
+// in without calling out to a geocoding service.
+//
+// The boundaries are read from a GeoJSON FeatureCollection of Polygon or
+// MultiPolygon features whose properties carry the level ("state" or "lga"),
+// the code and the name of the area, LGAs also carry the code of their
+// state. Deployments point BOUNDARIES_FILE at real admin-1 and admin-2
+// polygons, such as the GRID3 or OCHA COD-AB boundaries, converted to that
+// shape.
+//
+// testdata/boundaries.geojson is only for tests and local development, it
+// holds hand drawn rectangles around a few areas, which spill over into
+// neighbouring states.
+package geocode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+var ErrNoBoundariesFile = errors.New("error loading boundaries: no boundaries file was given")
+
+const (
+	AreaLevelState = "state"
+	AreaLevelLGA   = "lga"
+)
+
+type area struct {
+	code      string
+	stateCode string
+	box       geo.BoundingBox
+	// polygons are lists of rings, the first ring is the outline and the
+	// others are holes
+	polygons [][][]geo.Point
+}
+
+func (a area) contains(lat, lng float64) bool {
+	if !a.box.Contains(lat, lng) {
+		return false
+	}
+	for _, rings := range a.polygons {
+		if !geo.PolygonContains(rings[0], lat, lng) {
+			continue
+		}
+		inHole := false
+		for _, hole := range rings[1:] {
+			if geo.PolygonContains(hole, lat, lng) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// Geocoder is safe for concurrent use, it is never changed once loaded.
+type Geocoder struct {
+	states []area
+	lgas   []area
+}
+
+// NewGeocoder loads the boundaries in boundariesFile.
+func NewGeocoder(boundariesFile string) (*Geocoder, error) {
+	if boundariesFile == "" {
+		return &Geocoder{}, ErrNoBoundariesFile
+	}
+	data, err := os.ReadFile(boundariesFile)
+	if err != nil {
+		return &Geocoder{}, fmt.Errorf("error reading boundaries: %w", err)
+	}
+	return NewGeocoderFromGeoJSON(data)
+}
+
+func NewGeocoderFromGeoJSON(data []byte) (*Geocoder, error) {
+	var collection struct {
+		Features []struct {
+			Properties struct {
+				Level     string `json:"level"`
+				Code      string `json:"code"`
+				StateCode string `json:"state_code"`
+			} `json:"properties"`
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return &Geocoder{}, fmt.Errorf("error decoding boundaries: %w", err)
+	}
+
+	geocoder := &Geocoder{}
+	for _, feature := range collection.Features {
+		properties := feature.Properties
+		if properties.Code == "" {
+			return &Geocoder{}, errors.New("error loading boundaries: an area has no code")
+		}
+		polygons, err := decodePolygons(feature.Geometry.Type, feature.Geometry.Coordinates)
+		if err != nil {
+			return &Geocoder{}, fmt.Errorf("error loading the boundary of %s: %w", properties.Code, err)
+		}
+
+		points := []geo.Point{}
+		for _, rings := range polygons {
+			points = append(points, rings[0]...)
+		}
+		loaded := area{
+			code:      properties.Code,
+			stateCode: properties.StateCode,
+			box:       geo.BoundingBoxOf(points),
+			polygons:  polygons,
+		}
+		switch properties.Level {
+		case AreaLevelState:
+			geocoder.states = append(geocoder.states, loaded)
+		case AreaLevelLGA:
+			if loaded.stateCode == "" {
+				return &Geocoder{}, fmt.Errorf("error loading boundaries: lga %s has no state_code", properties.Code)
+			}
+			geocoder.lgas = append(geocoder.lgas, loaded)
+		default:
+			return &Geocoder{}, fmt.Errorf("error loading boundaries: %s has unknown level %q", properties.Code, properties.Level)
+		}
+	}
+	return geocoder, nil
+}
+
+// Locate returns the codes of the state and LGA the point is in, either is
+// empty when the point is outside every known area. The state of an LGA wins
+// over the state outlines, simplified outlines do not always line up.
+func (g *Geocoder) Locate(lat, lng float64) (stateCode, lgaCode string) {
+	for _, lga := range g.lgas {
+		if lga.contains(lat, lng) {
+			return lga.stateCode, lga.code
+		}
+	}
+	for _, state := range g.states {
+		if state.contains(lat, lng) {
+			return state.code, ""
+		}
+	}
+	return "", ""
+}
+
+// decodePolygons turns GeoJSON positions, longitude first, into rings of
+// points.
+func decodePolygons(geometryType string, coordinates json.RawMessage) ([][][]geo.Point, error) {
+	var positions [][][][2]float64
+	switch geometryType {
+	case "Polygon":
+		var polygon [][][2]float64
+		if err := json.Unmarshal(coordinates, &polygon); err != nil {
+			return nil, err
+		}
+		positions = [][][][2]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(coordinates, &positions); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", geometryType)
+	}
+
+	polygons := [][][]geo.Point{}
+	for _, polygon := range positions {
+		if len(polygon) == 0 {
+			return nil, errors.New("polygon has no rings")
+		}
+		rings := [][]geo.Point{}
+		for _, ring := range polygon {
+			if len(ring) < 3 {
+				return nil, errors.New("ring has fewer than 3 positions")
+			}
+			points := []geo.Point{}
+			for _, position := range ring {
+				points = append(points, geo.Point{Latitude: position[1], Longitude: position[0]})
+			}
+			rings = append(rings, points)
+		}
+		polygons = append(polygons, rings)
+	}
+	return polygons, nil
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/events"
+)
+
+const (
+	ReportExpiryJobName  = "expire-reports"
+	ReportExpiryInterval = 5 * time.Minute
+	reportExpiryBatch    = 500
+)
+
+// NewReportExpiryJob expires the reports that outlived the time to live of
+// their incident type. Expired reports leave the live feeds but can still be
+// looked up.
+func NewReportExpiryJob(reportRepo infra.ReportRepository, broadcaster *events.Broadcaster) (Job, error) {
+	if reportRepo == nil {
+		return Job{}, errors.New("ReportExpiryJob failed to initialize, reportRepo is nil")
+	}
+	if broadcaster == nil {
+		return Job{}, errors.New("ReportExpiryJob failed to initialize, broadcaster is nil")
+	}
+
+	return Job{
+		Name:     ReportExpiryJobName,
+		Interval: ReportExpiryInterval,
+		Run: func(ctx context.Context) error {
+			for {
+				expired, err := reportRepo.ExpireReports(ctx, time.Now(), reportExpiryBatch)
+				if err != nil {
+					return err
+				}
+				for _, report := range expired {
+					broadcaster.Publish(ctx, domain.ReportEventExpired, report)
+				}
+				if len(expired) < reportExpiryBatch {
+					return nil
+				}
+			}
+		},
+	}, nil
+}
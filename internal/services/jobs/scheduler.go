@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/rs/xid"
+	"go.uber.org/zap"
+)
+
+// Job is run every Interval by a single server instance.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+var ErrSchedulerStarted = errors.New("jobs cannot be registered once the scheduler started")
+
+// Scheduler runs the registered jobs in the background. Every instance
+// behind the load balancer runs a scheduler, a lock in the cache makes sure
+// each run of a job only happens on one of them.
+type Scheduler struct {
+	cache  infra.Cache
+	logger *zap.Logger
+	// instanceId is stored in the locks this instance holds
+	instanceId string
+
+	running sync.WaitGroup
+
+	mu      sync.Mutex
+	jobs    []Job
+	cancel  context.CancelFunc
+	started bool
+}
+
+func NewScheduler(cache infra.Cache, logger *zap.Logger) (*Scheduler, error) {
+	if cache == nil {
+		return &Scheduler{}, errors.New("Scheduler failed to initialize, cache is nil")
+	}
+	if logger == nil {
+		return &Scheduler{}, errors.New("Scheduler failed to initialize, logger is nil")
+	}
+	return &Scheduler{cache: cache, logger: logger, instanceId: xid.New().String()}, nil
+}
+
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Interval <= 0 || job.Run == nil {
+		return fmt.Errorf("invalid job %q, jobs need a name, an interval and a run function", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return ErrSchedulerStarted
+	}
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Start runs every job once straight away and then on its interval until
+// Close is called or ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.running.Add(1)
+		go func(job Job) {
+			defer s.running.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Close stops scheduling jobs and cancels the ones that are running.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Wait blocks until the jobs cancelled by Close have returned.
+func (s *Scheduler) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce holds the lock of the job for a whole interval instead of
+// releasing it when the job is done, so instances whose tickers fire a bit
+// later do not run the job again. The run is cut short before the lock
+// expires.
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	acquired, err := s.cache.SetOneIfNotExists(ctx, "jobs:"+job.Name+":lock", s.instanceId, job.Interval)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("unable to acquire job lock", zap.String("job", job.Name), zap.Error(err))
+		}
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, job.Interval)
+	defer cancel()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.logger.Error("job panicked", zap.String("job", job.Name), zap.Any("panic", recovered))
+		}
+	}()
+
+	startedAt := time.Now()
+	if err := job.Run(runCtx); err != nil && ctx.Err() == nil {
+		s.logger.Error("job failed", zap.String("job", job.Name), zap.Error(err))
+		return
+	}
+	s.logger.Debug("job finished", zap.String("job", job.Name), zap.Duration("took", time.Since(startedAt)))
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+// Dispatcher fans a notification out to every channel the user has not
+// turned off.
+type Dispatcher struct {
+	deviceRepo     infra.DeviceRepository
+	preferenceRepo infra.NotificationPreferenceRepository
+	notifiers      []infra.Notifier
+}
+
+func NewDispatcher(
+	deviceRepo infra.DeviceRepository,
+	preferenceRepo infra.NotificationPreferenceRepository,
+	notifiers ...infra.Notifier,
+) (*Dispatcher, error) {
+	if deviceRepo == nil {
+		return &Dispatcher{}, errors.New("Dispatcher failed to initialize, deviceRepo is nil")
+	}
+	if preferenceRepo == nil {
+		return &Dispatcher{}, errors.New("Dispatcher failed to initialize, preferenceRepo is nil")
+	}
+
+	channels := map[string]bool{}
+	for _, notifier := range notifiers {
+		if notifier == nil {
+			return &Dispatcher{}, errors.New("Dispatcher failed to initialize, notifier is nil")
+		}
+		if channels[notifier.Channel()] {
+			return &Dispatcher{}, fmt.Errorf("Dispatcher failed to initialize, channel %s is registered twice", notifier.Channel())
+		}
+		channels[notifier.Channel()] = true
+	}
+	return &Dispatcher{deviceRepo, preferenceRepo, notifiers}, nil
+}
+
+// Channels lists the channels users can choose from.
+func (d *Dispatcher) Channels() []string {
+	channels := []string{}
+	for _, notifier := range d.notifiers {
+		channels = append(channels, notifier.Channel())
+	}
+	return channels
+}
+
+// Dispatch tries every enabled channel even when one of them fails, the
+// failures are returned together.
+func (d *Dispatcher) Dispatch(ctx context.Context, user domain.User, notification infra.Notification) error {
+	preferences, err := d.preferenceRepo.GetNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	disabled := map[string]bool{}
+	for _, preference := range preferences {
+		disabled[preference.Channel] = !preference.Enabled
+	}
+
+	devices, err := d.deviceRepo.GetDevicesByUserId(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	recipient := infra.NotificationRecipient{User: user, Devices: devices}
+
+	var errs []error
+	for _, notifier := range d.notifiers {
+		if disabled[notifier.Channel()] {
+			continue
+		}
+		if err := notifier.Notify(ctx, recipient, notification); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Channel(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+// MailNotifier sends notifications through infra.MailService.
+type MailNotifier struct {
+	mailService infra.MailService
+}
+
+func NewMailNotifier(mailService infra.MailService) (*MailNotifier, error) {
+	if mailService == nil {
+		return &MailNotifier{}, errors.New("MailNotifier failed to initialize, mailService is nil")
+	}
+	return &MailNotifier{mailService}, nil
+}
+
+func (m *MailNotifier) Channel() string {
+	return domain.NotificationChannelEmail
+}
+
+func (m *MailNotifier) Notify(ctx context.Context, recipient infra.NotificationRecipient, notification infra.Notification) error {
+	return m.mailService.Send(ctx, infra.MailOptions{
+		To:      recipient.User.Email,
+		Subject: notification.Title,
+		Body:    notification.Body,
+	})
+}
@@ -0,0 +1,114 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"go.uber.org/zap"
+)
+
+// Dispatcher delivers a notification over every channel the user has
+// enabled.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, user domain.User, notification infra.Notification) error
+}
+
+const (
+	// maxDescriptionLength keeps alerts short enough for push payloads
+	maxDescriptionLength = 280
+	// MaxAlertAge is how long after an incident happened it is still worth
+	// alerting about, reports queued offline can arrive much later
+	MaxAlertAge = 2 * time.Hour
+)
+
+func toAlertNotification(subscription domain.AlertSubscription, report domain.Report) infra.Notification {
+	incidentType := strings.ReplaceAll(report.IncidentType, "_", " ")
+	description := report.Description
+	if runes := []rune(description); len(runes) > maxDescriptionLength {
+		description = string(runes[:maxDescriptionLength]) + "..."
+	}
+
+	return infra.Notification{
+		Title: fmt.Sprintf("%s reported near %s", incidentType, subscription.Name),
+		Body: fmt.Sprintf(
+			"A %s was reported near %s at %s.\n\n%s\n\nLocation: %s, %s",
+			incidentType, subscription.Name, report.HappenedAt().UTC().Format("15:04 MST, 2 Jan 2006"),
+			description, report.Latitude, report.Longitude,
+		),
+		Data: map[string]string{
+			"type":            "alert",
+			"report_id":       report.ID.String(),
+			"subscription_id": subscription.ID.String(),
+		},
+	}
+}
+
+// NotifyNewReport alerts the owners of every subscription the report
+// matches. It returns straight away, delivery happens in the background so a
+// slow channel never holds up the reporter. Reports of incidents that
+// happened more than MaxAlertAge ago are not alerted about.
+func (a *AlertService) NotifyNewReport(ctx context.Context, report domain.Report) {
+	if time.Since(report.HappenedAt()) > MaxAlertAge {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+		defer cancel()
+
+		if err := a.notifySubscribers(ctx, report); err != nil {
+			a.logger.Error("failed to notify alert subscribers",
+				zap.String("report_id", report.ID.String()), zap.Error(err))
+		}
+	}()
+}
+
+func (a *AlertService) notifySubscribers(ctx context.Context, report domain.Report) error {
+	subscriptions, err := a.alertRepo.GetAlertSubscriptionsNearPoint(ctx, report.Lat, report.Lng)
+	if err != nil {
+		return err
+	}
+
+	// a user is alerted once per report even when several of their
+	// subscriptions match it
+	notified := map[uuid.UUID]bool{}
+	for _, subscription := range matchingSubscriptions(subscriptions, report) {
+		if notified[subscription.UserID] {
+			continue
+		}
+		notified[subscription.UserID] = true
+
+		user, err := a.userRepo.GetUserByUserId(ctx, subscription.UserID)
+		if err != nil {
+			a.logger.Error("failed to load alert subscriber",
+				zap.String("subscription_id", subscription.ID.String()), zap.Error(err))
+			continue
+		}
+		if err := a.dispatcher.Dispatch(ctx, user, toAlertNotification(subscription, report)); err != nil {
+			a.logger.Error("failed to deliver alert",
+				zap.String("subscription_id", subscription.ID.String()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// matchingSubscriptions keeps the subscriptions that should be alerted
+// about the report, reporters are never alerted about their own reports.
+func matchingSubscriptions(subscriptions []domain.AlertSubscription, report domain.Report) []domain.AlertSubscription {
+	result := []domain.AlertSubscription{}
+	for _, subscription := range subscriptions {
+		if subscription.UserID == report.OwnerID ||
+			!subscription.MatchesIncidentType(report.IncidentType) ||
+			!subscription.Contains(report.Lat, report.Lng) ||
+			subscription.IsQuietAt(report.CreatedAt) {
+			continue
+		}
+		result = append(result, subscription)
+	}
+	return result
+}
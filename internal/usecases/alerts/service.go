@@ -0,0 +1,271 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// time zones are validated with time.LoadLocation, which should not
+	// depend on the zoneinfo of the host
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/usecases/incidenttypes"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+	"go.uber.org/zap"
+)
+
+type AlertService struct {
+	alertRepo           infra.AlertSubscriptionRepository
+	userRepo            infra.UserRepository
+	incidentTypeService *incidenttypes.IncidentTypeService
+	dispatcher          Dispatcher
+	logger              *zap.Logger
+}
+
+var (
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrInvalidShape         = errors.New("shape must be either circle or polygon")
+	ErrInvalidCenter        = errors.New("invalid circle center")
+	ErrInvalidRadius        = errors.New("radius must be between 100 and 50000 meters")
+	ErrInvalidPolygon       = errors.New("polygon must have between 3 and 50 valid points")
+	ErrInvalidIncidentType  = errors.New("invalid incident_type")
+	ErrInvalidQuietHours    = errors.New("quiet hours must both be set in HH:MM format")
+	ErrInvalidTimeZone      = errors.New("invalid time_zone")
+	ErrTooManySubscriptions = fmt.Errorf("you can not have more than %d alert subscriptions", MaxSubscriptionsPerUser)
+)
+
+const (
+	MaxSubscriptionsPerUser = 10
+	MinRadiusInMeters       = 100
+	MaxRadiusInMeters       = 50000
+	MinPolygonPoints        = 3
+	MaxPolygonPoints        = 50
+	notifyTimeout           = 30 * time.Second
+)
+
+// AlertSubscriptionInput is what a user sends to create or replace a
+// subscription. QuietHoursStart and QuietHoursEnd use the HH:MM format and
+// are both empty when the user does not want quiet hours.
+type AlertSubscriptionInput struct {
+	Name            string
+	Shape           string
+	Latitude        float64
+	Longitude       float64
+	RadiusInMeters  float64
+	Polygon         []geo.Point
+	IncidentTypes   []string
+	QuietHoursStart string
+	QuietHoursEnd   string
+	TimeZone        string
+}
+
+func NewAlertService(
+	alertRepo infra.AlertSubscriptionRepository,
+	userRepo infra.UserRepository,
+	incidentTypeService *incidenttypes.IncidentTypeService,
+	dispatcher Dispatcher,
+	logger *zap.Logger,
+) (*AlertService, error) {
+	if alertRepo == nil {
+		return &AlertService{}, errors.New("AlertService failed to initialize, alertRepo is nil")
+	}
+	if userRepo == nil {
+		return &AlertService{}, errors.New("AlertService failed to initialize, userRepo is nil")
+	}
+	if incidentTypeService == nil {
+		return &AlertService{}, errors.New("AlertService failed to initialize, incidentTypeService is nil")
+	}
+	if dispatcher == nil {
+		return &AlertService{}, errors.New("AlertService failed to initialize, dispatcher is nil")
+	}
+	if logger == nil {
+		return &AlertService{}, errors.New("AlertService failed to initialize, logger is nil")
+	}
+	return &AlertService{alertRepo, userRepo, incidentTypeService, dispatcher, logger}, nil
+}
+
+func (a *AlertService) CreateSubscription(ctx context.Context, input AlertSubscriptionInput) (domain.AlertSubscription, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.AlertSubscription{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	count, err := a.alertRepo.CountAlertSubscriptionsByUserId(ctx, jwtClaims.ID)
+	if err != nil {
+		return domain.AlertSubscription{}, err
+	}
+	if count >= MaxSubscriptionsPerUser {
+		return domain.AlertSubscription{}, ErrTooManySubscriptions
+	}
+
+	newSubscription := domain.AlertSubscription{
+		ID:        uuid.New(),
+		UserID:    jwtClaims.ID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := a.applyInput(ctx, &newSubscription, input); err != nil {
+		return domain.AlertSubscription{}, err
+	}
+
+	err = a.alertRepo.CreateAlertSubscription(ctx, newSubscription)
+	if err != nil {
+		return domain.AlertSubscription{}, err
+	}
+	return newSubscription, nil
+}
+
+func (a *AlertService) UpdateSubscription(
+	ctx context.Context, subscriptionId uuid.UUID, input AlertSubscriptionInput,
+) (domain.AlertSubscription, error) {
+	existingSubscription, err := a.GetSubscription(ctx, subscriptionId)
+	if err != nil {
+		return domain.AlertSubscription{}, err
+	}
+
+	if err := a.applyInput(ctx, &existingSubscription, input); err != nil {
+		return domain.AlertSubscription{}, err
+	}
+	existingSubscription.UpdatedAt = time.Now()
+
+	err = a.alertRepo.UpdateAlertSubscription(ctx, existingSubscription)
+	if err != nil {
+		return domain.AlertSubscription{}, err
+	}
+	return existingSubscription, nil
+}
+
+func (a *AlertService) DeleteSubscription(ctx context.Context, subscriptionId uuid.UUID) error {
+	existingSubscription, err := a.GetSubscription(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+	return a.alertRepo.DeleteAlertSubscription(ctx, existingSubscription.ID)
+}
+
+// GetSubscription only returns subscriptions of the logged in user, other
+// users' subscriptions are reported as not found.
+func (a *AlertService) GetSubscription(ctx context.Context, subscriptionId uuid.UUID) (domain.AlertSubscription, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.AlertSubscription{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	existingSubscription, err := a.alertRepo.GetAlertSubscriptionById(ctx, subscriptionId)
+	if err != nil {
+		return domain.AlertSubscription{}, err
+	}
+	if existingSubscription.UserID != jwtClaims.ID {
+		return domain.AlertSubscription{}, infra.ErrAlertSubscriptionNotFound
+	}
+	return existingSubscription, nil
+}
+
+func (a *AlertService) GetMySubscriptions(ctx context.Context) ([]domain.AlertSubscription, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.AlertSubscription{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	return a.alertRepo.GetAlertSubscriptionsByUserId(ctx, jwtClaims.ID)
+}
+
+func (a *AlertService) applyInput(ctx context.Context, subscription *domain.AlertSubscription, input AlertSubscriptionInput) error {
+	subscription.Name = input.Name
+	subscription.Shape = input.Shape
+	subscription.Latitude = 0
+	subscription.Longitude = 0
+	subscription.RadiusInMeters = 0
+	subscription.Polygon = []geo.Point{}
+
+	switch input.Shape {
+	case domain.AlertShapeCircle:
+		if !geo.IsValidCoordinate(input.Latitude, input.Longitude) {
+			return ErrInvalidCenter
+		}
+		if input.RadiusInMeters < MinRadiusInMeters || input.RadiusInMeters > MaxRadiusInMeters {
+			return ErrInvalidRadius
+		}
+		subscription.Latitude = input.Latitude
+		subscription.Longitude = input.Longitude
+		subscription.RadiusInMeters = input.RadiusInMeters
+	case domain.AlertShapePolygon:
+		if len(input.Polygon) < MinPolygonPoints || len(input.Polygon) > MaxPolygonPoints {
+			return ErrInvalidPolygon
+		}
+		for _, point := range input.Polygon {
+			if !geo.IsValidCoordinate(point.Latitude, point.Longitude) {
+				return ErrInvalidPolygon
+			}
+		}
+		subscription.Polygon = input.Polygon
+	default:
+		return ErrInvalidShape
+	}
+
+	incidentTypes := []string{}
+	for _, incidentType := range input.IncidentTypes {
+		_, err := a.incidentTypeService.GetActiveIncidentType(ctx, incidentType)
+		if err != nil {
+			if errors.Is(err, infra.ErrIncidentTypeNotFound) {
+				return ErrInvalidIncidentType
+			}
+			return err
+		}
+		incidentTypes = append(incidentTypes, incidentType)
+	}
+	subscription.IncidentTypes = incidentTypes
+
+	if (input.QuietHoursStart == "") != (input.QuietHoursEnd == "") {
+		return ErrInvalidQuietHours
+	}
+	quietHoursStart, quietHoursEnd := 0, 0
+	if input.QuietHoursStart != "" {
+		var err error
+		if quietHoursStart, err = parseTimeOfDay(input.QuietHoursStart); err != nil {
+			return err
+		}
+		if quietHoursEnd, err = parseTimeOfDay(input.QuietHoursEnd); err != nil {
+			return err
+		}
+	}
+	subscription.QuietHoursStart = quietHoursStart
+	subscription.QuietHoursEnd = quietHoursEnd
+
+	timeZone := input.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return ErrInvalidTimeZone
+	}
+	subscription.TimeZone = timeZone
+	return nil
+}
+
+// parseTimeOfDay converts HH:MM into minutes after midnight.
+func parseTimeOfDay(value string) (int, error) {
+	hours, minutes, found := strings.Cut(value, ":")
+	if !found || len(hours) != 2 || len(minutes) != 2 {
+		return 0, ErrInvalidQuietHours
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 23 {
+		return 0, ErrInvalidQuietHours
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, ErrInvalidQuietHours
+	}
+	return h*60 + m, nil
+}
+
+// FormatTimeOfDay converts minutes after midnight back into HH:MM.
+func FormatTimeOfDay(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,176 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+type CommentService struct {
+	commentRepo infra.CommentRepository
+	reportRepo  infra.ReportRepository
+}
+
+var (
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrInvalidParentComment = errors.New("replies can only be made to top level comments on the same report")
+	ErrNotCommentAuthor     = errors.New("only the author can modify this comment")
+)
+
+func NewCommentService(commentRepo infra.CommentRepository, reportRepo infra.ReportRepository) (*CommentService, error) {
+	if commentRepo == nil {
+		return &CommentService{}, errors.New("CommentService failed to initialize, commentRepo is nil")
+	}
+	if reportRepo == nil {
+		return &CommentService{}, errors.New("CommentService failed to initialize, reportRepo is nil")
+	}
+	return &CommentService{commentRepo, reportRepo}, nil
+}
+
+func (c *CommentService) CreateComment(
+	ctx context.Context, reportId, parentId uuid.UUID, body string,
+) (domain.Comment, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Comment{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	_, err := c.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if parentId != uuid.Nil {
+		parent, err := c.commentRepo.GetCommentByCommentId(ctx, parentId)
+		if err != nil {
+			if errors.Is(err, infra.ErrCommentNotFound) {
+				return domain.Comment{}, ErrInvalidParentComment
+			}
+			return domain.Comment{}, err
+		}
+		if parent.ReportID != reportId || parent.ParentID != uuid.Nil {
+			return domain.Comment{}, ErrInvalidParentComment
+		}
+	}
+
+	newComment := domain.Comment{
+		ID:        uuid.New(),
+		ReportID:  reportId,
+		AuthorID:  jwtClaims.ID,
+		ParentID:  parentId,
+		Body:      body,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err = c.commentRepo.CreateComment(ctx, newComment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return newComment, nil
+}
+
+func (c *CommentService) EditComment(
+	ctx context.Context, reportId, commentId uuid.UUID, body string,
+) (domain.Comment, error) {
+	existingComment, err := c.getOwnComment(ctx, reportId, commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	existingComment.Body = body
+	existingComment.UpdatedAt = time.Now()
+
+	err = c.commentRepo.UpdateComment(ctx, existingComment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return existingComment, nil
+}
+
+func (c *CommentService) DeleteComment(ctx context.Context, reportId, commentId uuid.UUID) error {
+	existingComment, err := c.getOwnComment(ctx, reportId, commentId)
+	if err != nil {
+		return err
+	}
+	return c.commentRepo.DeleteComment(ctx, existingComment.ID)
+}
+
+// GetComments pages through the top level comments of a report, each one is
+// returned with all of its replies.
+func (c *CommentService) GetComments(
+	ctx context.Context, reportId uuid.UUID, pageNumber, rowsPerPage int,
+) ([]domain.CommentThread, error) {
+	_, err := c.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return []domain.CommentThread{}, err
+	}
+
+	comments, err := c.commentRepo.GetCommentsByReportId(ctx, reportId, pageNumber, rowsPerPage)
+	if err != nil {
+		return []domain.CommentThread{}, err
+	}
+
+	parentIds := []uuid.UUID{}
+	for _, comment := range comments {
+		parentIds = append(parentIds, comment.ID)
+	}
+	replies, err := c.commentRepo.GetRepliesByParentIds(ctx, parentIds)
+	if err != nil {
+		return []domain.CommentThread{}, err
+	}
+
+	repliesByParent := map[uuid.UUID][]domain.Comment{}
+	for _, reply := range replies {
+		repliesByParent[reply.ParentID] = append(repliesByParent[reply.ParentID], reply)
+	}
+
+	threads := []domain.CommentThread{}
+	for _, comment := range comments {
+		threads = append(threads, domain.CommentThread{
+			Comment: comment,
+			Replies: repliesByParent[comment.ID],
+		})
+	}
+	return threads, nil
+}
+
+func (c *CommentService) getOwnComment(ctx context.Context, reportId, commentId uuid.UUID) (domain.Comment, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Comment{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	existingComment, err := c.commentRepo.GetCommentByCommentId(ctx, commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if existingComment.ReportID != reportId {
+		return domain.Comment{}, infra.ErrCommentNotFound
+	}
+	if existingComment.AuthorID != jwtClaims.ID {
+		return domain.Comment{}, ErrNotCommentAuthor
+	}
+	return existingComment, nil
+}
+
+// getVisibleReport fails with infra.ErrReportNotFound for deleted reports,
+// and for hidden reports unless the user is a moderator.
+func (c *CommentService) getVisibleReport(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	report, err := c.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	isModerator := ok && jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin)
+	if !report.IsVisible(isModerator) {
+		return domain.Report{}, infra.ErrReportNotFound
+	}
+	return report, nil
+}
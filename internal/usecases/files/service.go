@@ -7,30 +7,45 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/google/uuid"
 	"github.com/h2non/filetype"
+	"github.com/olad5/caution-companion/internal/domain"
 	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
 )
 
 type FileService struct {
 	fileStore infra.FileStore
+	fileRepo  infra.FileRepository
 }
 
-var ErrInvalidFileType = errors.New("invalid filetype")
+var (
+	ErrInvalidFileType = errors.New("invalid filetype")
+	ErrInvalidToken    = errors.New("invalid token")
+)
 
-func NewFileService(fileStore infra.FileStore) (*FileService, error) {
+func NewFileService(fileStore infra.FileStore, fileRepo infra.FileRepository) (*FileService, error) {
 	if fileStore == nil {
 		return &FileService{}, errors.New("FilesService failed to initialize, fileStore is nil")
 	}
-	return &FileService{fileStore}, nil
+	if fileRepo == nil {
+		return &FileService{}, errors.New("FilesService failed to initialize, fileRepo is nil")
+	}
+	return &FileService{fileStore, fileRepo}, nil
 }
 
-func (f *FileService) UploadFile(ctx context.Context, file io.Reader) (string, error) {
+func (f *FileService) UploadFile(ctx context.Context, file io.Reader) (domain.File, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.File{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
 	b, err := io.ReadAll(file)
 	if err != nil {
-		return "", fmt.Errorf("unable to save to file Store :%w", err)
+		return domain.File{}, fmt.Errorf("unable to save to file Store :%w", err)
 	}
 	mtype := mimetype.Detect(b)
 
@@ -44,18 +59,31 @@ func (f *FileService) UploadFile(ctx context.Context, file io.Reader) (string, e
 		}
 	}
 	if !isImageMimeType {
-		return "", fmt.Errorf("invalid mime type :%w", ErrInvalidFileType)
+		return domain.File{}, fmt.Errorf("invalid mime type :%w", ErrInvalidFileType)
 	}
 
 	if !filetype.IsImage(b) {
-		return "", fmt.Errorf("unable to save to file Store :%w", ErrInvalidFileType)
+		return domain.File{}, fmt.Errorf("unable to save to file Store :%w", ErrInvalidFileType)
 	}
 
+	fileId := uuid.New()
 	file = bytes.NewReader(b)
-	filename := strings.ReplaceAll(uuid.New().String(), "-", "")
+	filename := strings.ReplaceAll(fileId.String(), "-", "")
 	fileUrl, err := f.fileStore.SaveToFileStore(ctx, filename, file)
 	if err != nil {
-		return "", fmt.Errorf("unable to save to file Store :%w", err)
+		return domain.File{}, fmt.Errorf("unable to save to file Store :%w", err)
+	}
+
+	newFile := domain.File{
+		ID:        fileId,
+		OwnerID:   jwtClaims.ID,
+		Url:       fileUrl,
+		MimeType:  mtype.String(),
+		CreatedAt: time.Now(),
+	}
+	err = f.fileRepo.CreateFile(ctx, newFile)
+	if err != nil {
+		return domain.File{}, err
 	}
-	return fileUrl, nil
+	return newFile, nil
 }
@@ -0,0 +1,181 @@
+package incidenttypes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"go.uber.org/zap"
+)
+
+type IncidentTypeService struct {
+	incidentTypeRepo infra.IncidentTypeRepository
+	cache            infra.Cache
+	logger           *zap.Logger
+}
+
+var (
+	ErrIncidentTypeAlreadyExists = errors.New("incident type already exist")
+	ErrInvalidSlug               = errors.New("slug must only contain lowercase letters, digits and underscores")
+)
+
+const (
+	activeIncidentTypesCacheKey = "incident-types:active"
+	incidentTypesCacheTTL       = 10 * time.Minute
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func NewIncidentTypeService(
+	incidentTypeRepo infra.IncidentTypeRepository, cache infra.Cache, logger *zap.Logger,
+) (*IncidentTypeService, error) {
+	if incidentTypeRepo == nil {
+		return &IncidentTypeService{}, errors.New("IncidentTypeService failed to initialize, incidentTypeRepo is nil")
+	}
+	if cache == nil {
+		return &IncidentTypeService{}, errors.New("IncidentTypeService failed to initialize, cache is nil")
+	}
+	if logger == nil {
+		return &IncidentTypeService{}, errors.New("IncidentTypeService failed to initialize, logger is nil")
+	}
+	return &IncidentTypeService{incidentTypeRepo, cache, logger}, nil
+}
+
+func (i *IncidentTypeService) GetActiveIncidentTypes(ctx context.Context) ([]domain.IncidentType, error) {
+	if cached, err := i.cache.GetOne(ctx, activeIncidentTypesCacheKey); err == nil {
+		var incidentTypes []domain.IncidentType
+		if err := json.Unmarshal([]byte(cached), &incidentTypes); err == nil {
+			return incidentTypes, nil
+		}
+	}
+
+	incidentTypes, err := i.incidentTypeRepo.GetIncidentTypes(ctx, false)
+	if err != nil {
+		return []domain.IncidentType{}, err
+	}
+
+	encoded, err := json.Marshal(incidentTypes)
+	if err != nil {
+		return []domain.IncidentType{}, fmt.Errorf("error encoding incident types: %w", err)
+	}
+	// the cache only saves a query, reports can still be made without it
+	if err := i.cache.SetOne(ctx, activeIncidentTypesCacheKey, string(encoded), incidentTypesCacheTTL); err != nil {
+		i.logger.Error("error caching incident types", zap.Error(err))
+	}
+	return incidentTypes, nil
+}
+
+func (i *IncidentTypeService) GetAllIncidentTypes(ctx context.Context) ([]domain.IncidentType, error) {
+	return i.incidentTypeRepo.GetIncidentTypes(ctx, true)
+}
+
+func (i *IncidentTypeService) GetActiveIncidentType(ctx context.Context, slug string) (domain.IncidentType, error) {
+	incidentTypes, err := i.GetActiveIncidentTypes(ctx)
+	if err != nil {
+		return domain.IncidentType{}, err
+	}
+	for _, incidentType := range incidentTypes {
+		if incidentType.Slug == slug {
+			return incidentType, nil
+		}
+	}
+	return domain.IncidentType{}, infra.ErrIncidentTypeNotFound
+}
+
+func (i *IncidentTypeService) CreateIncidentType(
+	ctx context.Context, slug, displayName string, defaultSeverity int, icon string, allowsAnonymous bool, timeToLive time.Duration,
+) (domain.IncidentType, error) {
+	if !slugPattern.MatchString(slug) {
+		return domain.IncidentType{}, ErrInvalidSlug
+	}
+
+	_, err := i.incidentTypeRepo.GetIncidentTypeBySlug(ctx, slug)
+	if err == nil {
+		return domain.IncidentType{}, ErrIncidentTypeAlreadyExists
+	}
+	if !errors.Is(err, infra.ErrIncidentTypeNotFound) {
+		return domain.IncidentType{}, err
+	}
+
+	newIncidentType := domain.IncidentType{
+		Slug:            slug,
+		DisplayName:     displayName,
+		DefaultSeverity: defaultSeverity,
+		Icon:            icon,
+		IsActive:        true,
+		AllowsAnonymous: allowsAnonymous,
+		TimeToLive:      timeToLive,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	err = i.incidentTypeRepo.CreateIncidentType(ctx, newIncidentType)
+	if err != nil {
+		if errors.Is(err, infra.ErrIncidentTypeExists) {
+			return domain.IncidentType{}, ErrIncidentTypeAlreadyExists
+		}
+		return domain.IncidentType{}, err
+	}
+	i.invalidateCache(ctx)
+	return newIncidentType, nil
+}
+
+// UpdateIncidentType keeps whether the incident type allows anonymous reports
+// and its time to live when allowsAnonymous or timeToLive are nil.
+func (i *IncidentTypeService) UpdateIncidentType(
+	ctx context.Context, slug, displayName string, defaultSeverity int, icon string, isActive bool, allowsAnonymous *bool, timeToLive *time.Duration,
+) (domain.IncidentType, error) {
+	existingIncidentType, err := i.incidentTypeRepo.GetIncidentTypeBySlug(ctx, slug)
+	if err != nil {
+		return domain.IncidentType{}, err
+	}
+
+	existingIncidentType.DisplayName = displayName
+	existingIncidentType.DefaultSeverity = defaultSeverity
+	existingIncidentType.Icon = icon
+	existingIncidentType.IsActive = isActive
+	if allowsAnonymous != nil {
+		existingIncidentType.AllowsAnonymous = *allowsAnonymous
+	}
+	if timeToLive != nil {
+		existingIncidentType.TimeToLive = *timeToLive
+	}
+	existingIncidentType.UpdatedAt = time.Now()
+
+	err = i.incidentTypeRepo.UpdateIncidentType(ctx, existingIncidentType)
+	if err != nil {
+		return domain.IncidentType{}, err
+	}
+	i.invalidateCache(ctx)
+	return existingIncidentType, nil
+}
+
+func (i *IncidentTypeService) RetireIncidentType(ctx context.Context, slug string) error {
+	existingIncidentType, err := i.incidentTypeRepo.GetIncidentTypeBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	existingIncidentType.IsActive = false
+	existingIncidentType.UpdatedAt = time.Now()
+
+	err = i.incidentTypeRepo.UpdateIncidentType(ctx, existingIncidentType)
+	if err != nil {
+		return err
+	}
+	i.invalidateCache(ctx)
+	return nil
+}
+
+// invalidateCache only logs failures, the change is already saved and the
+// cache expires on its own after incidentTypesCacheTTL.
+func (i *IncidentTypeService) invalidateCache(ctx context.Context) {
+	if err := i.cache.DeleteOne(ctx, activeIncidentTypesCacheKey); err != nil {
+		i.logger.Error("error invalidating incident types cache", zap.Error(err))
+	}
+}
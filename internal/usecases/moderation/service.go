@@ -0,0 +1,303 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/services/events"
+)
+
+type ModerationService struct {
+	moderationRepo infra.ModerationRepository
+	reportRepo     infra.ReportRepository
+	userRepo       infra.UserRepository
+	broadcaster    *events.Broadcaster
+}
+
+const (
+	MaxFlagNoteLength   = 500
+	MaxSuspensionInDays = 365
+)
+
+var (
+	ErrInvalidToken        = errors.New("invalid token")
+	ErrModeratorsOnly      = errors.New("only moderators can moderate reports")
+	ErrInvalidFlagReason   = errors.New("reason must be one of abusive, fake, doxxing, spam or other")
+	ErrFlagNoteTooLong     = fmt.Errorf("note must be at most %d characters", MaxFlagNoteLength)
+	ErrFlagOwnReport       = errors.New("you cannot flag your own report")
+	ErrReportAlreadyHidden = errors.New("report is already hidden")
+	ErrInvalidSuspension   = fmt.Errorf("suspensions must last between 1 and %d days", MaxSuspensionInDays)
+	ErrCannotModerateStaff = errors.New("moderators and admins cannot be warned or suspended")
+)
+
+var flagReasons = map[string]bool{
+	domain.FlagReasonAbusive: true,
+	domain.FlagReasonFake:    true,
+	domain.FlagReasonDoxxing: true,
+	domain.FlagReasonSpam:    true,
+	domain.FlagReasonOther:   true,
+}
+
+func NewModerationService(
+	moderationRepo infra.ModerationRepository,
+	reportRepo infra.ReportRepository,
+	userRepo infra.UserRepository,
+	broadcaster *events.Broadcaster,
+) (*ModerationService, error) {
+	if moderationRepo == nil {
+		return &ModerationService{}, errors.New("ModerationService failed to initialize, moderationRepo is nil")
+	}
+	if reportRepo == nil {
+		return &ModerationService{}, errors.New("ModerationService failed to initialize, reportRepo is nil")
+	}
+	if userRepo == nil {
+		return &ModerationService{}, errors.New("ModerationService failed to initialize, userRepo is nil")
+	}
+	if broadcaster == nil {
+		return &ModerationService{}, errors.New("ModerationService failed to initialize, broadcaster is nil")
+	}
+	return &ModerationService{moderationRepo, reportRepo, userRepo, broadcaster}, nil
+}
+
+// FlagReport puts the report in the moderation queue. A user can flag a
+// report again once moderators acted on their previous flag.
+func (m *ModerationService) FlagReport(ctx context.Context, reportId uuid.UUID, reason, note string) (domain.ReportFlag, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.ReportFlag{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if !flagReasons[reason] {
+		return domain.ReportFlag{}, ErrInvalidFlagReason
+	}
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > MaxFlagNoteLength {
+		return domain.ReportFlag{}, ErrFlagNoteTooLong
+	}
+
+	report, err := m.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.ReportFlag{}, err
+	}
+	if report.IsDeleted() || report.IsHidden() {
+		return domain.ReportFlag{}, infra.ErrReportNotFound
+	}
+	if !report.IsAnonymous && report.OwnerID == jwtClaims.ID {
+		return domain.ReportFlag{}, ErrFlagOwnReport
+	}
+
+	flag := domain.ReportFlag{
+		ID:        uuid.New(),
+		ReportID:  report.ID,
+		UserID:    jwtClaims.ID,
+		Reason:    reason,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+	if err := m.moderationRepo.CreateReportFlag(ctx, flag); err != nil {
+		return domain.ReportFlag{}, err
+	}
+	return flag, nil
+}
+
+// GetModerationQueue returns a page of the reports waiting for a moderator
+// along with the number of reports in the queue.
+func (m *ModerationService) GetModerationQueue(ctx context.Context, pageNumber, rowsPerPage int) ([]domain.FlaggedReport, int, error) {
+	if _, err := moderatorClaims(ctx); err != nil {
+		return []domain.FlaggedReport{}, 0, err
+	}
+
+	queue, err := m.moderationRepo.GetModerationQueue(ctx, pageNumber, rowsPerPage)
+	if err != nil {
+		return []domain.FlaggedReport{}, 0, err
+	}
+	total, err := m.moderationRepo.CountModerationQueue(ctx)
+	if err != nil {
+		return []domain.FlaggedReport{}, 0, err
+	}
+	return queue, total, nil
+}
+
+// HideReport takes the report out of every listing without deleting it.
+func (m *ModerationService) HideReport(ctx context.Context, reportId uuid.UUID, reason string) (domain.Report, error) {
+	report, err := m.applyReportAction(ctx, reportId, domain.ModerationActionHide, reason)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	m.broadcaster.Publish(ctx, domain.ReportEventDeleted, report)
+	return report, nil
+}
+
+// RestoreReport brings a hidden report back, it also dismisses the pending
+// flags of a report that was never hidden.
+func (m *ModerationService) RestoreReport(ctx context.Context, reportId uuid.UUID, reason string) (domain.Report, error) {
+	existing, err := m.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	report, err := m.applyReportAction(ctx, reportId, domain.ModerationActionRestore, reason)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if existing.IsHidden() {
+		m.broadcaster.Publish(ctx, domain.ReportEventCreated, report)
+	}
+	return report, nil
+}
+
+func (m *ModerationService) DeleteReport(ctx context.Context, reportId uuid.UUID, reason string) (domain.Report, error) {
+	report, err := m.applyReportAction(ctx, reportId, domain.ModerationActionDelete, reason)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	m.broadcaster.Publish(ctx, domain.ReportEventDeleted, report)
+	return report, nil
+}
+
+// WarnReporter warns the owner of the report, anonymous owners included
+// without revealing who they are.
+func (m *ModerationService) WarnReporter(ctx context.Context, reportId uuid.UUID, reason string) (domain.ModerationAction, error) {
+	return m.applyUserAction(ctx, reportId, domain.ModerationAction{Action: domain.ModerationActionWarn, Reason: reason})
+}
+
+// SuspendReporter stops the owner of the report from making reports for the
+// given number of days.
+func (m *ModerationService) SuspendReporter(ctx context.Context, reportId uuid.UUID, reason string, days int) (domain.ModerationAction, error) {
+	if days < 1 || days > MaxSuspensionInDays {
+		return domain.ModerationAction{}, ErrInvalidSuspension
+	}
+	return m.applyUserAction(ctx, reportId, domain.ModerationAction{
+		Action:         domain.ModerationActionSuspend,
+		Reason:         reason,
+		SuspendedUntil: time.Now().AddDate(0, 0, days),
+	})
+}
+
+// GetModerationActions is the moderation history of a report. The owner of
+// an anonymous report is only shown to admins.
+func (m *ModerationService) GetModerationActions(ctx context.Context, reportId uuid.UUID) ([]domain.ModerationAction, error) {
+	jwtClaims, err := moderatorClaims(ctx)
+	if err != nil {
+		return []domain.ModerationAction{}, err
+	}
+	report, err := m.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return []domain.ModerationAction{}, err
+	}
+
+	actions, err := m.moderationRepo.GetModerationActionsByReportId(ctx, reportId)
+	if err != nil {
+		return []domain.ModerationAction{}, err
+	}
+	if report.IsAnonymous && !jwtClaims.HasRole(domain.RoleAdmin) {
+		for i := range actions {
+			actions[i].UserID = uuid.Nil
+		}
+	}
+	return actions, nil
+}
+
+// GetContentFindings is what the content filter found in the report,
+// including the text it masked.
+func (m *ModerationService) GetContentFindings(ctx context.Context, reportId uuid.UUID) ([]domain.ContentFinding, error) {
+	if _, err := moderatorClaims(ctx); err != nil {
+		return []domain.ContentFinding{}, err
+	}
+	if _, err := m.reportRepo.GetReportByReportId(ctx, reportId); err != nil {
+		return []domain.ContentFinding{}, err
+	}
+	return m.reportRepo.GetContentFindings(ctx, reportId)
+}
+
+func (m *ModerationService) applyReportAction(ctx context.Context, reportId uuid.UUID, action, reason string) (domain.Report, error) {
+	jwtClaims, err := moderatorClaims(ctx)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	report, err := m.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if action == domain.ModerationActionHide && report.IsHidden() {
+		return domain.Report{}, ErrReportAlreadyHidden
+	}
+	ownerId, err := m.reportOwner(ctx, report)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	err = m.moderationRepo.ApplyReportAction(ctx, domain.ModerationAction{
+		ID:          uuid.New(),
+		ModeratorID: jwtClaims.ID,
+		Action:      action,
+		ReportID:    report.ID,
+		UserID:      ownerId,
+		Reason:      strings.TrimSpace(reason),
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return domain.Report{}, err
+	}
+	return m.reportRepo.GetReportByReportId(ctx, reportId)
+}
+
+func (m *ModerationService) applyUserAction(ctx context.Context, reportId uuid.UUID, action domain.ModerationAction) (domain.ModerationAction, error) {
+	jwtClaims, err := moderatorClaims(ctx)
+	if err != nil {
+		return domain.ModerationAction{}, err
+	}
+	report, err := m.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.ModerationAction{}, err
+	}
+	ownerId, err := m.reportOwner(ctx, report)
+	if err != nil {
+		return domain.ModerationAction{}, err
+	}
+	owner, err := m.userRepo.GetUserByUserId(ctx, ownerId)
+	if err != nil {
+		return domain.ModerationAction{}, err
+	}
+	if owner.Role == domain.RoleModerator || owner.Role == domain.RoleAdmin {
+		return domain.ModerationAction{}, ErrCannotModerateStaff
+	}
+
+	action.ID = uuid.New()
+	action.ModeratorID = jwtClaims.ID
+	action.ReportID = report.ID
+	action.UserID = owner.ID
+	action.Reason = strings.TrimSpace(action.Reason)
+	action.CreatedAt = time.Now()
+	if err := m.moderationRepo.ApplyUserAction(ctx, action); err != nil {
+		return domain.ModerationAction{}, err
+	}
+	if report.IsAnonymous && !jwtClaims.HasRole(domain.RoleAdmin) {
+		action.UserID = uuid.Nil
+	}
+	return action, nil
+}
+
+func (m *ModerationService) reportOwner(ctx context.Context, report domain.Report) (uuid.UUID, error) {
+	if !report.IsAnonymous {
+		return report.OwnerID, nil
+	}
+	return m.reportRepo.GetAnonymousReportOwner(ctx, report.ID)
+}
+
+func moderatorClaims(ctx context.Context) (auth.JWTClaims, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return auth.JWTClaims{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if !jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin) {
+		return auth.JWTClaims{}, ErrModeratorsOnly
+	}
+	return jwtClaims, nil
+}
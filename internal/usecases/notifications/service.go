@@ -0,0 +1,194 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/services/notify"
+)
+
+type NotificationService struct {
+	deviceRepo     infra.DeviceRepository
+	preferenceRepo infra.NotificationPreferenceRepository
+	dispatcher     *notify.Dispatcher
+	// webPushKey is empty when web push is not enabled
+	webPushKey string
+}
+
+var (
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrInvalidPlatform    = errors.New("platform must be web")
+	ErrInvalidDeviceToken = errors.New("web push tokens must be https subscription endpoints")
+	ErrMissingDeviceKeys  = errors.New("web push devices need both the p256dh and auth keys")
+	ErrUnknownChannel     = errors.New("unknown notification channel")
+	ErrWebPushDisabled    = errors.New("web push is not enabled")
+	ErrTooManyDevices     = fmt.Errorf("you can not register more than %d devices", MaxDevicesPerUser)
+)
+
+const MaxDevicesPerUser = 20
+
+func NewNotificationService(
+	deviceRepo infra.DeviceRepository,
+	preferenceRepo infra.NotificationPreferenceRepository,
+	dispatcher *notify.Dispatcher,
+	webPushKey string,
+) (*NotificationService, error) {
+	if deviceRepo == nil {
+		return &NotificationService{}, errors.New("NotificationService failed to initialize, deviceRepo is nil")
+	}
+	if preferenceRepo == nil {
+		return &NotificationService{}, errors.New("NotificationService failed to initialize, preferenceRepo is nil")
+	}
+	if dispatcher == nil {
+		return &NotificationService{}, errors.New("NotificationService failed to initialize, dispatcher is nil")
+	}
+	return &NotificationService{deviceRepo, preferenceRepo, dispatcher, webPushKey}, nil
+}
+
+func (n *NotificationService) WebPushKey() (string, error) {
+	if n.webPushKey == "" {
+		return "", ErrWebPushDisabled
+	}
+	return n.webPushKey, nil
+}
+
+// RegisterDevice is safe to call every time the app starts, registering a
+// token again only refreshes it.
+func (n *NotificationService) RegisterDevice(
+	ctx context.Context, platform, token, p256dh, authSecret, userAgent string,
+) (domain.Device, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Device{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	if platform != domain.DevicePlatformWeb {
+		return domain.Device{}, ErrInvalidPlatform
+	}
+	endpoint, err := url.Parse(token)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return domain.Device{}, ErrInvalidDeviceToken
+	}
+	if p256dh == "" || authSecret == "" {
+		return domain.Device{}, ErrMissingDeviceKeys
+	}
+
+	devices, err := n.deviceRepo.GetDevicesByUserId(ctx, jwtClaims.ID)
+	if err != nil {
+		return domain.Device{}, err
+	}
+	isRegistered := false
+	for _, device := range devices {
+		if device.Token == token {
+			isRegistered = true
+		}
+	}
+	if !isRegistered && len(devices) >= MaxDevicesPerUser {
+		return domain.Device{}, ErrTooManyDevices
+	}
+
+	return n.deviceRepo.SaveDevice(ctx, domain.Device{
+		ID:        uuid.New(),
+		UserID:    jwtClaims.ID,
+		Platform:  platform,
+		Token:     token,
+		P256dh:    p256dh,
+		Auth:      authSecret,
+		UserAgent: userAgent,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (n *NotificationService) GetMyDevices(ctx context.Context) ([]domain.Device, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.Device{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	return n.deviceRepo.GetDevicesByUserId(ctx, jwtClaims.ID)
+}
+
+// RemoveDevice reports devices of other users as not found.
+func (n *NotificationService) RemoveDevice(ctx context.Context, deviceId uuid.UUID) error {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	device, err := n.deviceRepo.GetDeviceById(ctx, deviceId)
+	if err != nil {
+		return err
+	}
+	if device.UserID != jwtClaims.ID {
+		return infra.ErrDeviceNotFound
+	}
+	return n.deviceRepo.DeleteDevice(ctx, device.ID)
+}
+
+// GetMyPreferences returns a preference for every available channel,
+// channels the user never changed are enabled.
+func (n *NotificationService) GetMyPreferences(ctx context.Context) ([]domain.NotificationPreference, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.NotificationPreference{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	saved, err := n.preferenceRepo.GetNotificationPreferences(ctx, jwtClaims.ID)
+	if err != nil {
+		return []domain.NotificationPreference{}, err
+	}
+	savedByChannel := map[string]domain.NotificationPreference{}
+	for _, preference := range saved {
+		savedByChannel[preference.Channel] = preference
+	}
+
+	preferences := []domain.NotificationPreference{}
+	for _, channel := range n.dispatcher.Channels() {
+		preference, ok := savedByChannel[channel]
+		if !ok {
+			preference = domain.NotificationPreference{UserID: jwtClaims.ID, Channel: channel, Enabled: true}
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences only changes the channels that are given.
+func (n *NotificationService) UpdatePreferences(
+	ctx context.Context, enabledByChannel map[string]bool,
+) ([]domain.NotificationPreference, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.NotificationPreference{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	available := map[string]bool{}
+	for _, channel := range n.dispatcher.Channels() {
+		available[channel] = true
+	}
+
+	changes := []domain.NotificationPreference{}
+	for channel, enabled := range enabledByChannel {
+		if !available[channel] {
+			return []domain.NotificationPreference{}, fmt.Errorf("%w: %s", ErrUnknownChannel, channel)
+		}
+		changes = append(changes, domain.NotificationPreference{
+			UserID:    jwtClaims.ID,
+			Channel:   channel,
+			Enabled:   enabled,
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	if err := n.preferenceRepo.SaveNotificationPreferences(ctx, changes); err != nil {
+		return []domain.NotificationPreference{}, err
+	}
+	return n.GetMyPreferences(ctx)
+}
@@ -0,0 +1,109 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/services/events"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+type RealtimeService struct {
+	reportService *reports.ReportService
+	cache         infra.Cache
+}
+
+var (
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrInvalidLocation = errors.New("invalid location")
+	ErrInvalidEventID  = errors.New("event_id must have a value")
+)
+
+const (
+	lastAckCacheKeyPrefix  = "realtime-ack:"
+	lastAckCacheTTL        = 24 * time.Hour
+	locationCacheKeyPrefix = "user-location:"
+	locationCacheTTL       = time.Hour
+)
+
+type UserLocation struct {
+	Latitude  float64   `json:"lat"`
+	Longitude float64   `json:"lng"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewRealtimeService(reportService *reports.ReportService, cache infra.Cache) (*RealtimeService, error) {
+	if reportService == nil {
+		return &RealtimeService{}, errors.New("RealtimeService failed to initialize, reportService is nil")
+	}
+	if cache == nil {
+		return &RealtimeService{}, errors.New("RealtimeService failed to initialize, cache is nil")
+	}
+	return &RealtimeService{reportService, cache}, nil
+}
+
+// Connect subscribes a session to every report event. Sessions that do not
+// say where to resume from continue after the last event the user
+// acknowledged, on any server instance.
+func (s *RealtimeService) Connect(
+	ctx context.Context, lastEventID string,
+) (*events.Subscription, []domain.ReportEvent, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return nil, []domain.ReportEvent{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	if lastEventID == "" {
+		if acked, err := s.cache.GetOne(ctx, lastAckCacheKey(jwtClaims.ID)); err == nil {
+			lastEventID = acked
+		}
+	}
+	return s.reportService.SubscribeToReportEvents(reports.ReportEventFilter{}, lastEventID)
+}
+
+func (s *RealtimeService) Acknowledge(ctx context.Context, eventID string) error {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if eventID == "" {
+		return ErrInvalidEventID
+	}
+	return s.cache.SetOne(ctx, lastAckCacheKey(jwtClaims.ID), eventID, lastAckCacheTTL)
+}
+
+func (s *RealtimeService) UpdateLocation(ctx context.Context, latitude, longitude float64) error {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if !geo.IsValidCoordinate(latitude, longitude) {
+		return ErrInvalidLocation
+	}
+
+	encoded, err := json.Marshal(UserLocation{
+		Latitude:  latitude,
+		Longitude: longitude,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding user location: %w", err)
+	}
+	return s.cache.SetOne(ctx, locationCacheKey(jwtClaims.ID), string(encoded), locationCacheTTL)
+}
+
+func lastAckCacheKey(userId uuid.UUID) string {
+	return lastAckCacheKeyPrefix + userId.String()
+}
+
+func locationCacheKey(userId uuid.UUID) string {
+	return locationCacheKeyPrefix + userId.String()
+}
@@ -0,0 +1,67 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+const (
+	MaxAnonymousReportsPerHour = 3
+	MaxAnonymousReportsPerDay  = 10
+)
+
+var (
+	ErrAnonymityNotAllowed  = errors.New("this incident type cannot be reported anonymously")
+	ErrTooManyAnonymous     = errors.New("too many anonymous reports, try again later")
+	ErrOwnerLookupForbidden = errors.New("only admins can look up the owner of an anonymous report")
+)
+
+// checkAnonymousRateLimit is stricter than anything applied to regular
+// reports since anonymous reports cannot be traced back publicly.
+func (r *ReportService) checkAnonymousRateLimit(ctx context.Context, ownerId uuid.UUID) error {
+	now := time.Now()
+	for window, limit := range map[time.Duration]int{
+		time.Hour:      MaxAnonymousReportsPerHour,
+		24 * time.Hour: MaxAnonymousReportsPerDay,
+	} {
+		count, err := r.reportRepo.CountAnonymousReportsByOwnerSince(ctx, ownerId, now.Add(-window))
+		if err != nil {
+			return err
+		}
+		if count >= limit {
+			return ErrTooManyAnonymous
+		}
+	}
+	return nil
+}
+
+// isReportOwner also recognises the owner of an anonymous report, without
+// the owner ever being put on the report.
+func (r *ReportService) isReportOwner(ctx context.Context, report domain.Report, userId uuid.UUID) (bool, error) {
+	if !report.IsAnonymous {
+		return report.OwnerID == userId, nil
+	}
+	ownerId, err := r.reportRepo.GetAnonymousReportOwner(ctx, report.ID)
+	if err != nil {
+		return false, err
+	}
+	return ownerId == userId, nil
+}
+
+// GetAnonymousReportOwner is for admins handling abuse.
+func (r *ReportService) GetAnonymousReportOwner(ctx context.Context, reportId uuid.UUID) (uuid.UUID, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if !jwtClaims.HasRole(domain.RoleAdmin) {
+		return uuid.Nil, ErrOwnerLookupForbidden
+	}
+	return r.reportRepo.GetAnonymousReportOwner(ctx, reportId)
+}
@@ -0,0 +1,137 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+const (
+	MaxBatchReports = 50
+	// MaxOccurredAtAge is how long a report can sit queued on a phone
+	MaxOccurredAtAge = 7 * 24 * time.Hour
+	// occurredAtClockSkew allows for phones whose clocks run a little fast
+	occurredAtClockSkew = 5 * time.Minute
+)
+
+var (
+	ErrEmptyBatch        = errors.New("a batch must hold at least one report")
+	ErrBatchTooLarge     = fmt.Errorf("a batch can hold at most %d reports", MaxBatchReports)
+	ErrMissingClientID   = errors.New("client_id is required")
+	ErrClientIDRemoved   = errors.New("the report with this client_id was removed")
+	ErrInvalidOccurredAt = errors.New("occurred_at cannot be in the future or more than 7 days ago")
+	// ErrOccurredAtExpired keeps reports that would expire as soon as they
+	// are made out of the feeds
+	ErrOccurredAtExpired = errors.New("occurred_at is older than the time to live of the incident type")
+)
+
+// BatchReport is a report the app queued while offline.
+type BatchReport struct {
+	IncidentType string
+	Longitude    string
+	Latitude     string
+	Description  string
+	// Options must carry the ClientID of the report
+	Options CreateReportOptions
+}
+
+// BatchReportResult is the outcome of one report of a batch. Duplicate is
+// true when the report was made by an earlier sync, Report is then the
+// report that was made. Reports made by an earlier sync that were deleted or
+// hidden since fail with ErrClientIDRemoved.
+type BatchReportResult struct {
+	ClientID  uuid.UUID
+	Report    domain.Report
+	Duplicate bool
+	Err       error
+}
+
+// CreateReports makes each report of the batch like CreateReport does, a
+// report failing does not stop the others. Reports whose client id was
+// already used by the user are not made again, so a batch can be synced
+// again safely.
+func (r *ReportService) CreateReports(ctx context.Context, batch []BatchReport) ([]BatchReportResult, error) {
+	if _, ok := auth.GetJWTClaims(ctx); !ok {
+		return []BatchReportResult{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if len(batch) == 0 {
+		return []BatchReportResult{}, ErrEmptyBatch
+	}
+	if len(batch) > MaxBatchReports {
+		return []BatchReportResult{}, ErrBatchTooLarge
+	}
+
+	results := []BatchReportResult{}
+	for _, item := range batch {
+		report, duplicate, err := r.createBatchReport(ctx, item)
+		results = append(results, BatchReportResult{
+			ClientID:  item.Options.ClientID,
+			Report:    report,
+			Duplicate: duplicate,
+			Err:       err,
+		})
+	}
+	return results, nil
+}
+
+func (r *ReportService) createBatchReport(ctx context.Context, item BatchReport) (domain.Report, bool, error) {
+	if item.Options.ClientID == uuid.Nil {
+		return domain.Report{}, false, ErrMissingClientID
+	}
+
+	existing, found, err := r.getReportByClientId(ctx, item.Options.ClientID)
+	if err != nil || found {
+		return existing, found, err
+	}
+
+	report, err := r.CreateReport(ctx, item.IncidentType, item.Longitude, item.Latitude, item.Description, item.Options)
+	if errors.Is(err, infra.ErrReportClientIDExists) {
+		// another sync of the same batch made the report first
+		return r.getReportByClientId(ctx, item.Options.ClientID)
+	}
+	return report, false, err
+}
+
+// getReportByClientId only finds reports made by the user.
+func (r *ReportService) getReportByClientId(ctx context.Context, clientId uuid.UUID) (domain.Report, bool, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Report{}, false, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	report, err := r.reportRepo.GetReportByClientId(ctx, jwtClaims.ID, clientId)
+	if err != nil {
+		if errors.Is(err, infra.ErrReportNotFound) {
+			return domain.Report{}, false, nil
+		}
+		return domain.Report{}, false, err
+	}
+	if !report.IsVisible(false) {
+		return domain.Report{}, false, ErrClientIDRemoved
+	}
+	if err := r.loadMedia(ctx, &report); err != nil {
+		return domain.Report{}, false, err
+	}
+	return report, true, nil
+}
+
+// validateOccurredAt accepts a zero time, reports made straight away do not
+// need it. A timeToLive of 0 means reports never expire.
+func validateOccurredAt(occurredAt, now time.Time, timeToLive time.Duration) error {
+	if occurredAt.IsZero() {
+		return nil
+	}
+	if occurredAt.After(now.Add(occurredAtClockSkew)) || occurredAt.Before(now.Add(-MaxOccurredAtAge)) {
+		return ErrInvalidOccurredAt
+	}
+	if timeToLive > 0 && occurredAt.Before(now.Add(-timeToLive)) {
+		return ErrOccurredAtExpired
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package reports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrContentNotAllowed = errors.New("description contains language that is not allowed")
+
+// ContentRule is one step of the content filter. It returns the text with
+// anything it masks replaced, along with what it found. Findings only need
+// the rule, language, action and match, the filter fills in the rest.
+type ContentRule interface {
+	Apply(text string) (string, []domain.ContentFinding)
+}
+
+// ContentFilter runs report descriptions through its rules in order, each
+// rule sees the text the rules before it left so masked text is not matched
+// twice.
+type ContentFilter struct {
+	rules []ContentRule
+}
+
+func NewContentFilter(rules ...ContentRule) *ContentFilter {
+	return &ContentFilter{rules: rules}
+}
+
+// NewDefaultContentFilter masks phone numbers and emails, then applies the
+// word lists in wordListFile when it is set.
+func NewDefaultContentFilter(wordListFile string) (*ContentFilter, error) {
+	rules := []ContentRule{NewPhoneNumberRule(), NewEmailRule()}
+	if wordListFile != "" {
+		wordLists, err := LoadWordListRules(wordListFile)
+		if err != nil {
+			return &ContentFilter{}, err
+		}
+		rules = append(rules, wordLists...)
+	}
+	return NewContentFilter(rules...), nil
+}
+
+// Filter fails with ErrContentNotAllowed when a rule rejects the text.
+func (f *ContentFilter) Filter(text string) (string, []domain.ContentFinding, error) {
+	findings := []domain.ContentFinding{}
+	for _, rule := range f.rules {
+		var found []domain.ContentFinding
+		text, found = rule.Apply(text)
+		for _, finding := range found {
+			if finding.Action == domain.ContentActionReject {
+				return "", []domain.ContentFinding{}, ErrContentNotAllowed
+			}
+		}
+		findings = append(findings, found...)
+	}
+	return text, findings, nil
+}
+
+// ownContentFindings ties the findings to the report they were found in.
+func ownContentFindings(reportId uuid.UUID, findings []domain.ContentFinding) []domain.ContentFinding {
+	now := time.Now()
+	for i := range findings {
+		findings[i].ID = uuid.New()
+		findings[i].ReportID = reportId
+		findings[i].CreatedAt = now
+	}
+	return findings
+}
+
+// patternRule masks every match of a pattern that accept agrees with.
+type patternRule struct {
+	name    string
+	mask    string
+	pattern *regexp.Regexp
+	accept  func(match string) bool
+}
+
+func (p patternRule) Apply(text string) (string, []domain.ContentFinding) {
+	findings := []domain.ContentFinding{}
+	masked := p.pattern.ReplaceAllStringFunc(text, func(match string) string {
+		if p.accept != nil && !p.accept(match) {
+			return match
+		}
+		findings = append(findings, domain.ContentFinding{
+			Rule:   p.name,
+			Action: domain.ContentActionMask,
+			Match:  match,
+		})
+		return p.mask
+	})
+	return masked, findings
+}
+
+// NewPhoneNumberRule masks local and international phone numbers written
+// with or without spaces, dashes and brackets between the digits.
+func NewPhoneNumberRule() ContentRule {
+	return patternRule{
+		name:    "phone_number",
+		mask:    "[phone number removed]",
+		pattern: regexp.MustCompile(`\+?\(?\d[\d ()-]{7,18}\d`),
+		accept: func(match string) bool {
+			digits := 0
+			for _, char := range match {
+				if unicode.IsDigit(char) {
+					digits++
+				}
+			}
+			return digits >= 10 && digits <= 15
+		},
+	}
+}
+
+func NewEmailRule() ContentRule {
+	return patternRule{
+		name:    "email",
+		mask:    "[email removed]",
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	}
+}
+
+// WordListRule matches whole words and phrases regardless of case and tone
+// marks, so a Yoruba word is matched whether or not the reporter wrote its
+// diacritics.
+type WordListRule struct {
+	language string
+	action   string
+	// phrases are keyed by their first word
+	phrases map[string][][]string
+}
+
+func NewWordListRule(language, action string, words []string) (*WordListRule, error) {
+	switch action {
+	case domain.ContentActionMask, domain.ContentActionFlag, domain.ContentActionReject:
+	default:
+		return &WordListRule{}, fmt.Errorf("invalid word list action %q, must be one of mask, flag or reject", action)
+	}
+
+	rule := &WordListRule{language: language, action: action, phrases: map[string][][]string{}}
+	for _, word := range words {
+		tokens := []string{}
+		for _, token := range tokenize(word) {
+			tokens = append(tokens, normalizeWord(token.text))
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		rule.phrases[tokens[0]] = append(rule.phrases[tokens[0]], tokens)
+	}
+	return rule, nil
+}
+
+func (w *WordListRule) Apply(text string) (string, []domain.ContentFinding) {
+	tokens := tokenize(text)
+	normalized := make([]string, len(tokens))
+	for i, token := range tokens {
+		normalized[i] = normalizeWord(token.text)
+	}
+
+	findings := []domain.ContentFinding{}
+	var masked strings.Builder
+	written := 0
+	for i := 0; i < len(tokens); i++ {
+		length := w.matchAt(normalized[i:])
+		if length == 0 {
+			continue
+		}
+		start, end := tokens[i].start, tokens[i+length-1].end
+		findings = append(findings, domain.ContentFinding{
+			Rule:     "word_list",
+			Language: w.language,
+			Action:   w.action,
+			Match:    text[start:end],
+		})
+		if w.action == domain.ContentActionMask {
+			masked.WriteString(text[written:start])
+			masked.WriteString("***")
+			written = end
+		}
+		i += length - 1
+	}
+	masked.WriteString(text[written:])
+	return masked.String(), findings
+}
+
+// matchAt returns the number of words of the longest phrase the words start
+// with.
+func (w *WordListRule) matchAt(words []string) int {
+	longest := 0
+	for _, phrase := range w.phrases[words[0]] {
+		if len(phrase) <= longest || len(phrase) > len(words) {
+			continue
+		}
+		matches := true
+		for i := range phrase {
+			if phrase[i] != words[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			longest = len(phrase)
+		}
+	}
+	return longest
+}
+
+// LoadWordListRules reads word lists from a JSON file of the form
+//
+//	{"lists": [{"language": "yo", "action": "reject", "words": ["..."]}]}
+//
+// Languages are free form, the codes en, pcm, yo, ha and ig are used for
+// English, Pidgin, Yoruba, Hausa and Igbo.
+func LoadWordListRules(path string) ([]ContentRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []ContentRule{}, fmt.Errorf("error reading word lists: %w", err)
+	}
+
+	var file struct {
+		Lists []struct {
+			Language string   `json:"language"`
+			Action   string   `json:"action"`
+			Words    []string `json:"words"`
+		} `json:"lists"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return []ContentRule{}, fmt.Errorf("error decoding word lists: %w", err)
+	}
+
+	rules := []ContentRule{}
+	for _, list := range file.Lists {
+		rule, err := NewWordListRule(list.Language, list.Action, list.Words)
+		if err != nil {
+			return []ContentRule{}, fmt.Errorf("error loading the %s word list: %w", list.Language, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits text into words, tone marks stay part of the word they
+// are on.
+func tokenize(text string) []token {
+	tokens := []token{}
+	start := -1
+	for i, char := range text {
+		isWordChar := unicode.IsLetter(char) || unicode.IsDigit(char) || unicode.IsMark(char)
+		if isWordChar && start < 0 {
+			start = i
+		}
+		if !isWordChar && start >= 0 {
+			tokens = append(tokens, token{text[start:i], start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{text[start:], start, len(text)})
+	}
+	return tokens
+}
+
+// hausaLetters are the hooked letters of Hausa, often typed as their plain
+// forms.
+var hausaLetters = strings.NewReplacer("ɓ", "b", "ɗ", "d", "ƙ", "k", "ƴ", "y")
+
+// normalizeWord lower cases the word and drops tone marks and the dots under
+// Yoruba and Igbo vowels.
+func normalizeWord(word string) string {
+	stripMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(stripMarks, strings.ToLower(word))
+	if err != nil {
+		folded = strings.ToLower(word)
+	}
+	return hausaLetters.Replace(folded)
+}
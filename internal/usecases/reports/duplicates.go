@@ -0,0 +1,277 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+const (
+	// a new report is a likely duplicate of a primary report of the same
+	// incident type made within DuplicateRadiusInMeters and DuplicateWindow
+	// whose description is at least MinDescriptionSimilarity similar
+	DuplicateRadiusInMeters  = 300
+	DuplicateWindow          = 30 * time.Minute
+	MinDescriptionSimilarity = 0.3
+	maxDuplicates            = 5
+	// duplicateCandidates bounds how many nearby reports are compared
+	duplicateCandidates = 50
+)
+
+var (
+	ErrLikelyDuplicate        = errors.New("this report looks like a duplicate of an existing report")
+	ErrMergeIntoItself        = errors.New("a report cannot be merged into itself")
+	ErrMergeIntoCorroboration = errors.New("reports can only be merged into primary reports")
+	ErrNotCorroboration       = errors.New("only reports merged into another report can be split")
+	ErrModeratorsOnly         = errors.New("only moderators can merge or split reports")
+)
+
+// DuplicateReportsError is returned by CreateReport when duplicate checks
+// were requested and likely duplicates exist, nothing is stored.
+type DuplicateReportsError struct {
+	Duplicates []domain.Report
+}
+
+func (d *DuplicateReportsError) Error() string {
+	return ErrLikelyDuplicate.Error()
+}
+
+func (d *DuplicateReportsError) Unwrap() error {
+	return ErrLikelyDuplicate
+}
+
+// findDuplicates returns the primary reports the new report most likely
+// duplicates, most similar first.
+func (r *ReportService) findDuplicates(ctx context.Context, report domain.Report) ([]domain.Report, error) {
+	query := infra.NearbyReportsQuery{
+		Latitude:       report.Lat,
+		Longitude:      report.Lng,
+		RadiusInMeters: DuplicateRadiusInMeters,
+		IncidentType:   report.IncidentType,
+		CreatedAfter:   report.CreatedAt.Add(-DuplicateWindow),
+	}
+	candidates, err := r.reportRepo.GetNearbyReports(ctx, query, 1, duplicateCandidates)
+	if err != nil {
+		return []domain.Report{}, err
+	}
+
+	type match struct {
+		report     domain.Report
+		similarity float64
+	}
+	matches := []match{}
+	for _, candidate := range candidates {
+		similarity := descriptionSimilarity(report.Description, candidate.Description)
+		if similarity >= MinDescriptionSimilarity {
+			matches = append(matches, match{candidate.Report, similarity})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].similarity > matches[j].similarity
+	})
+
+	duplicates := []domain.Report{}
+	for i := 0; i < len(matches) && i < maxDuplicates; i++ {
+		duplicates = append(duplicates, matches[i].report)
+	}
+	if err := r.loadMedia(ctx, toReportPointers(duplicates)...); err != nil {
+		return []domain.Report{}, err
+	}
+	return duplicates, nil
+}
+
+// resolvePrimary returns the primary report a new report corroborating
+// reportId should be linked to.
+func (r *ReportService) resolvePrimary(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	primary, err := r.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if primary.IsCorroboration() {
+		primary, err = r.reportRepo.GetReportByReportId(ctx, primary.DuplicateOf)
+		if err != nil {
+			return domain.Report{}, err
+		}
+	}
+	if !primary.IsVisible(false) {
+		return domain.Report{}, infra.ErrReportNotFound
+	}
+	return primary, nil
+}
+
+// MergeReport turns the report, along with everything corroborating it,
+// into corroborations of primaryId. The merged reports are kept as they
+// are, only the link is recorded.
+func (r *ReportService) MergeReport(ctx context.Context, reportId, primaryId uuid.UUID) (domain.Report, error) {
+	jwtClaims, err := moderatorClaims(ctx)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if reportId == primaryId {
+		return domain.Report{}, ErrMergeIntoItself
+	}
+
+	report, err := r.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	primary, err := r.getVisibleReport(ctx, primaryId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if primary.IsCorroboration() {
+		return domain.Report{}, ErrMergeIntoCorroboration
+	}
+	if report.DuplicateOf == primary.ID {
+		return r.GetReportByReportId(ctx, primary.ID)
+	}
+
+	err = r.reportRepo.LinkReport(ctx, domain.ReportLink{
+		ID:                uuid.New(),
+		ReportID:          report.ID,
+		PrimaryID:         primary.ID,
+		PreviousPrimaryID: report.DuplicateOf,
+		Action:            domain.ReportLinkMerge,
+		ActorID:           jwtClaims.ID,
+		CreatedAt:         time.Now(),
+	})
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	report.DuplicateOf = primary.ID
+	r.broadcaster.Publish(ctx, domain.ReportEventDeleted, report)
+	return r.publishPrimary(ctx, primary.ID)
+}
+
+// SplitReport makes a merged report a primary report again.
+func (r *ReportService) SplitReport(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	jwtClaims, err := moderatorClaims(ctx)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	report, err := r.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if !report.IsCorroboration() {
+		return domain.Report{}, ErrNotCorroboration
+	}
+
+	err = r.reportRepo.LinkReport(ctx, domain.ReportLink{
+		ID:                uuid.New(),
+		ReportID:          report.ID,
+		PreviousPrimaryID: report.DuplicateOf,
+		Action:            domain.ReportLinkSplit,
+		ActorID:           jwtClaims.ID,
+		CreatedAt:         time.Now(),
+	})
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	if _, err := r.publishPrimary(ctx, report.DuplicateOf); err != nil {
+		return domain.Report{}, err
+	}
+	return r.publishPrimary(ctx, report.ID)
+}
+
+// GetCorroborations lists the reports linked to a primary report.
+func (r *ReportService) GetCorroborations(ctx context.Context, reportId uuid.UUID) ([]domain.Report, error) {
+	if _, err := r.GetReportByReportId(ctx, reportId); err != nil {
+		return []domain.Report{}, err
+	}
+
+	corroborations, err := r.reportRepo.GetCorroborations(ctx, reportId)
+	if err != nil {
+		return []domain.Report{}, err
+	}
+	if err := r.loadMedia(ctx, toReportPointers(corroborations)...); err != nil {
+		return []domain.Report{}, err
+	}
+	return corroborations, nil
+}
+
+// GetReportLinks is the merge history of a report, for moderators.
+func (r *ReportService) GetReportLinks(ctx context.Context, reportId uuid.UUID) ([]domain.ReportLink, error) {
+	if _, err := moderatorClaims(ctx); err != nil {
+		return []domain.ReportLink{}, err
+	}
+	if _, err := r.getVisibleReport(ctx, reportId); err != nil {
+		return []domain.ReportLink{}, err
+	}
+	return r.reportRepo.GetReportLinks(ctx, reportId)
+}
+
+// publishPrimary reloads a report whose corroborations changed and tells
+// subscribers about it, unless it was deleted or hidden since.
+func (r *ReportService) publishPrimary(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	report, err := r.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if err := r.loadMedia(ctx, &report); err != nil {
+		return domain.Report{}, err
+	}
+	if report.IsVisible(false) {
+		r.broadcaster.Publish(ctx, domain.ReportEventUpdated, report)
+	}
+	return report, nil
+}
+
+func moderatorClaims(ctx context.Context) (auth.JWTClaims, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return auth.JWTClaims{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if !jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin) {
+		return auth.JWTClaims{}, ErrModeratorsOnly
+	}
+	return jwtClaims, nil
+}
+
+// descriptionSimilarity is the Jaccard similarity of the significant words
+// of both descriptions.
+func descriptionSimilarity(a, b string) float64 {
+	wordsA, wordsB := significantWords(a), significantWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "was": true, "were": true,
+	"there": true, "this": true, "that": true, "with": true, "near": true, "from": true,
+	"have": true, "has": true, "just": true, "some": true, "now": true, "our": true,
+}
+
+func significantWords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	}) {
+		if len([]rune(word)) < 3 || stopWords[word] {
+			continue
+		}
+		words[word] = true
+	}
+	return words
+}
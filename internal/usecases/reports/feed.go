@@ -0,0 +1,218 @@
+package reports
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+const (
+	MaxFeedLimit = 100
+	// MaxExportLimit is the most reports EachReportFeedPage walks in one go
+	MaxExportLimit = 5000
+)
+
+var (
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrInvalidLimit       = fmt.Errorf("rows must be between 1 and %d", MaxFeedLimit)
+	ErrInvalidExportLimit = fmt.Errorf("limit must be between 1 and %d", MaxExportLimit)
+)
+
+type ReportFeedPage struct {
+	Reports []domain.Report
+	// NextCursor is empty on the last page
+	NextCursor string
+	Total      int
+}
+
+// feedCursor is handed to clients as opaque base64 encoded JSON. It records
+// the sort it was issued for so it cannot be replayed against another order.
+type feedCursor struct {
+	Sort             string    `json:"s"`
+	CreatedAt        time.Time `json:"t"`
+	CredibilityScore float64   `json:"c"`
+	ID               uuid.UUID `json:"id"`
+}
+
+func encodeFeedCursor(sortBy string, report domain.Report) string {
+	cursor := feedCursor{
+		Sort:             sortBy,
+		CreatedAt:        report.CreatedAt,
+		CredibilityScore: report.CredibilityScore,
+		ID:               report.ID,
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeFeedCursor(sortBy, value string) (infra.ReportFeedCursor, error) {
+	if value == "" {
+		return infra.ReportFeedCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return infra.ReportFeedCursor{}, ErrInvalidCursor
+	}
+	var cursor feedCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return infra.ReportFeedCursor{}, ErrInvalidCursor
+	}
+	if cursor.Sort != sortBy || cursor.ID == uuid.Nil {
+		return infra.ReportFeedCursor{}, ErrInvalidCursor
+	}
+
+	return infra.ReportFeedCursor{
+		CreatedAt:        cursor.CreatedAt,
+		CredibilityScore: cursor.CredibilityScore,
+		ID:               cursor.ID,
+	}, nil
+}
+
+func (r *ReportService) GetReportFeed(
+	ctx context.Context,
+	query infra.ReportFeedQuery,
+	sortBy, cursor string,
+	limit int,
+) (ReportFeedPage, error) {
+	if sortBy == "" {
+		sortBy = infra.ReportSortLatest
+	}
+	if limit < 1 || limit > MaxFeedLimit {
+		return ReportFeedPage{}, ErrInvalidLimit
+	}
+	if err := r.validateReportFeedQuery(ctx, query, sortBy); err != nil {
+		return ReportFeedPage{}, err
+	}
+
+	after, err := decodeFeedCursor(sortBy, cursor)
+	if err != nil {
+		return ReportFeedPage{}, err
+	}
+
+	// one extra report tells us whether there is another page
+	reports, err := r.reportRepo.GetReportFeed(ctx, query, sortBy, after, limit+1)
+	if err != nil {
+		return ReportFeedPage{}, err
+	}
+	total, err := r.reportRepo.Count(ctx, query)
+	if err != nil {
+		return ReportFeedPage{}, err
+	}
+
+	result := ReportFeedPage{Reports: reports, Total: total}
+	if len(reports) > limit {
+		result.Reports = reports[:limit]
+		result.NextCursor = encodeFeedCursor(sortBy, result.Reports[limit-1])
+	}
+	if err := r.loadMedia(ctx, toReportPointers(result.Reports)...); err != nil {
+		return ReportFeedPage{}, err
+	}
+	return result, nil
+}
+
+// EachReportFeedPage walks up to limit reports of the feed from cursor
+// onwards, MaxFeedLimit reports at a time, so exports never hold all of it
+// in memory. visit is called at least once, with an empty page when nothing
+// matches, and the walk stops at the first error it returns. The cursor of
+// the reports after the limit is returned, it is empty when there are none.
+func (r *ReportService) EachReportFeedPage(
+	ctx context.Context,
+	query infra.ReportFeedQuery,
+	sortBy, cursor string,
+	limit int,
+	visit func(reports []domain.Report) error,
+) (string, error) {
+	if sortBy == "" {
+		sortBy = infra.ReportSortLatest
+	}
+	if limit < 1 || limit > MaxExportLimit {
+		return "", ErrInvalidExportLimit
+	}
+	if err := r.validateReportFeedQuery(ctx, query, sortBy); err != nil {
+		return "", err
+	}
+	after, err := decodeFeedCursor(sortBy, cursor)
+	if err != nil {
+		return "", err
+	}
+
+	visited := 0
+	for {
+		pageSize := min(MaxFeedLimit, limit-visited)
+		// one extra report tells us whether there is another page
+		reports, err := r.reportRepo.GetReportFeed(ctx, query, sortBy, after, pageSize+1)
+		if err != nil {
+			return "", err
+		}
+		hasMore := len(reports) > pageSize
+		if hasMore {
+			reports = reports[:pageSize]
+		}
+		if err := r.loadMedia(ctx, toReportPointers(reports)...); err != nil {
+			return "", err
+		}
+		if err := visit(reports); err != nil {
+			return "", err
+		}
+		visited += len(reports)
+		if !hasMore {
+			return "", nil
+		}
+
+		last := reports[len(reports)-1]
+		if visited >= limit {
+			return encodeFeedCursor(sortBy, last), nil
+		}
+		after = infra.ReportFeedCursor{
+			CreatedAt:        last.CreatedAt,
+			CredibilityScore: last.CredibilityScore,
+			ID:               last.ID,
+		}
+	}
+}
+
+func (r *ReportService) validateReportFeedQuery(ctx context.Context, query infra.ReportFeedQuery, sortBy string) error {
+	if sortBy != infra.ReportSortLatest &&
+		sortBy != infra.ReportSortOldest &&
+		sortBy != infra.ReportSortCredibility {
+		return ErrInvalidSort
+	}
+	if query.Status != "" && !IsValidStatus(query.Status) {
+		return ErrInvalidStatus
+	}
+	return r.ensureHistoryVisible(ctx, query.OwnerID)
+}
+
+// ensureHistoryVisible stops the owner filter from being used to read the
+// history of users who have hidden it.
+func (r *ReportService) ensureHistoryVisible(ctx context.Context, ownerId uuid.UUID) error {
+	if ownerId == uuid.Nil {
+		return nil
+	}
+
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	if ownerId == jwtClaims.ID || jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin) {
+		return nil
+	}
+
+	owner, err := r.userRepo.GetUserByUserId(ctx, ownerId)
+	if err != nil {
+		return err
+	}
+	if owner.HideReports {
+		return ErrReportHistoryPrivate
+	}
+	return nil
+}
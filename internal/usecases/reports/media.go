@@ -0,0 +1,127 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+const MaxMediaPerReport = 10
+
+var (
+	ErrMediaNotFound = errors.New("one or more media files do not exist")
+	ErrMediaNotOwned = errors.New("you can only attach files you uploaded")
+	ErrTooManyMedia  = fmt.Errorf("a report cannot have more than %d media files", MaxMediaPerReport)
+)
+
+// AttachMedia is only allowed to the owner of the report.
+func (r *ReportService) AttachMedia(
+	ctx context.Context, reportId uuid.UUID, fileIds []uuid.UUID,
+) (domain.Report, error) {
+	existingReport, err := r.getOwnReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if err := r.loadMedia(ctx, &existingReport); err != nil {
+		return domain.Report{}, err
+	}
+
+	media, err := r.resolveMedia(ctx, existingReport, fileIds)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if err := r.reportRepo.AddReportMedia(ctx, media); err != nil {
+		return domain.Report{}, err
+	}
+
+	existingReport.Media = append(existingReport.Media, media...)
+	r.broadcaster.Publish(ctx, domain.ReportEventUpdated, existingReport)
+	return existingReport, nil
+}
+
+// resolveMedia turns uploaded file ids into media entries positioned after
+// the media already attached to the report. Every file must belong to the
+// logged in user.
+func (r *ReportService) resolveMedia(
+	ctx context.Context, report domain.Report, fileIds []uuid.UUID,
+) ([]domain.ReportMedia, error) {
+	if len(fileIds) == 0 {
+		return []domain.ReportMedia{}, nil
+	}
+
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.ReportMedia{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	uniqueFileIds := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for _, media := range report.Media {
+		seen[media.FileID] = true
+	}
+	for _, fileId := range fileIds {
+		if !seen[fileId] {
+			seen[fileId] = true
+			uniqueFileIds = append(uniqueFileIds, fileId)
+		}
+	}
+	if len(report.Media)+len(uniqueFileIds) > MaxMediaPerReport {
+		return []domain.ReportMedia{}, ErrTooManyMedia
+	}
+
+	files, err := r.fileRepo.GetFilesByFileIds(ctx, uniqueFileIds)
+	if err != nil {
+		return []domain.ReportMedia{}, err
+	}
+	filesById := map[uuid.UUID]domain.File{}
+	for _, file := range files {
+		filesById[file.ID] = file
+	}
+
+	result := []domain.ReportMedia{}
+	for i, fileId := range uniqueFileIds {
+		file, ok := filesById[fileId]
+		if !ok {
+			return []domain.ReportMedia{}, ErrMediaNotFound
+		}
+		if file.OwnerID != jwtClaims.ID {
+			return []domain.ReportMedia{}, ErrMediaNotOwned
+		}
+		result = append(result, domain.ReportMedia{
+			ReportID:  report.ID,
+			FileID:    file.ID,
+			OwnerID:   file.OwnerID,
+			Url:       file.Url,
+			MimeType:  file.MimeType,
+			Position:  len(report.Media) + i,
+			CreatedAt: time.Now(),
+		})
+	}
+	return result, nil
+}
+
+func (r *ReportService) loadMedia(ctx context.Context, reports ...*domain.Report) error {
+	reportIds := []uuid.UUID{}
+	for _, report := range reports {
+		reportIds = append(reportIds, report.ID)
+	}
+
+	media, err := r.reportRepo.GetReportMedia(ctx, reportIds)
+	if err != nil {
+		return err
+	}
+
+	mediaByReport := map[uuid.UUID][]domain.ReportMedia{}
+	for _, element := range media {
+		mediaByReport[element.ReportID] = append(mediaByReport[element.ReportID], element)
+	}
+	for _, report := range reports {
+		report.Media = mediaByReport[report.ID]
+	}
+	return nil
+}
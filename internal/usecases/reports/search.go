@@ -0,0 +1,47 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+)
+
+const MaxSearchQueryLength = 200
+
+var ErrInvalidSearchQuery = errors.New("q must be between 1 and 200 characters")
+
+func (r *ReportService) SearchReports(
+	ctx context.Context,
+	text, incidentType string,
+	createdAfter, createdBefore time.Time,
+	pageNumber, rowsPerPage int,
+) ([]domain.ReportSearchResult, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > MaxSearchQueryLength {
+		return []domain.ReportSearchResult{}, ErrInvalidSearchQuery
+	}
+
+	query := infra.SearchReportsQuery{
+		Text:          text,
+		IncidentType:  incidentType,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+	}
+	results, err := r.reportRepo.SearchReports(ctx, query, pageNumber, rowsPerPage)
+	if err != nil {
+		return []domain.ReportSearchResult{}, err
+	}
+
+	pointers := []*domain.Report{}
+	for i := range results {
+		pointers = append(pointers, &results[i].Report)
+	}
+	if err := r.loadMedia(ctx, pointers...); err != nil {
+		return []domain.ReportSearchResult{}, err
+	}
+	return results, nil
+}
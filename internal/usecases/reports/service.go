@@ -3,60 +3,358 @@ package reports
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/olad5/caution-companion/internal/domain"
 	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/services/events"
+	"github.com/olad5/caution-companion/internal/usecases/incidenttypes"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+	"go.uber.org/zap"
 )
 
 type ReportService struct {
-	reportRepo infra.ReportRepository
+	reportRepo          infra.ReportRepository
+	incidentTypeService *incidenttypes.IncidentTypeService
+	fileRepo            infra.FileRepository
+	userRepo            infra.UserRepository
+	broadcaster         *events.Broadcaster
+	alerter             ReportAlerter
+	cache               infra.Cache
+	geocoder            ReportGeocoder
+	contentFilter       *ContentFilter
+	logger              *zap.Logger
 }
 
-var ErrInvalidIncidentType = errors.New("invalid incident_type")
+// ReportAlerter is told about every new report so that users watching the
+// area can be alerted, it must not block the caller.
+type ReportAlerter interface {
+	NotifyNewReport(ctx context.Context, report domain.Report)
+}
+
+// ReportGeocoder tags reports with the state and LGA they were made in, the
+// codes are empty when the point is outside every known area.
+type ReportGeocoder interface {
+	Locate(lat, lng float64) (stateCode, lgaCode string)
+}
+
+var (
+	ErrInvalidIncidentType = errors.New("invalid incident_type")
+	ErrInvalidLocation     = errors.New("invalid location")
+	ErrInvalidRadius       = errors.New("radius must be between 1 and 50000 meters")
+	ErrInvalidBoundingBox  = errors.New("invalid bounding box")
+	ErrInvalidZoom         = errors.New("zoom must be between 0 and 22")
+	ErrInvalidSort         = errors.New("sort must be one of latest, oldest or credibility")
+	ErrNotReportOwner      = errors.New("only the owner of a report can modify it")
+	ErrUserSuspended       = errors.New("your account is suspended from making reports")
+)
 
-func NewReportsService(reportRepo infra.ReportRepository) (*ReportService, error) {
+const (
+	MaxNearbyRadiusInMeters = 50000
+	MaxZoom                 = 22
+	// reports are always returned individually from this zoom level upwards
+	MinUnclusteredZoom    = 16
+	MaxUnclusteredReports = 200
+	clusterCellsPerTile   = 8
+)
+
+func NewReportsService(
+	reportRepo infra.ReportRepository,
+	incidentTypeService *incidenttypes.IncidentTypeService,
+	fileRepo infra.FileRepository,
+	userRepo infra.UserRepository,
+	broadcaster *events.Broadcaster,
+	alerter ReportAlerter,
+	cache infra.Cache,
+	geocoder ReportGeocoder,
+	contentFilter *ContentFilter,
+	logger *zap.Logger,
+) (*ReportService, error) {
 	if reportRepo == nil {
 		return &ReportService{}, errors.New("ReportService failed to initialize, reportRepo is nil")
 	}
-	return &ReportService{reportRepo}, nil
+	if incidentTypeService == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, incidentTypeService is nil")
+	}
+	if fileRepo == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, fileRepo is nil")
+	}
+	if userRepo == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, userRepo is nil")
+	}
+	if broadcaster == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, broadcaster is nil")
+	}
+	if alerter == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, alerter is nil")
+	}
+	if cache == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, cache is nil")
+	}
+	if geocoder == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, geocoder is nil")
+	}
+	if contentFilter == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, contentFilter is nil")
+	}
+	if logger == nil {
+		return &ReportService{}, errors.New("ReportService failed to initialize, logger is nil")
+	}
+	return &ReportService{reportRepo, incidentTypeService, fileRepo, userRepo, broadcaster, alerter, cache, geocoder, contentFilter, logger}, nil
+}
+
+// CreateReportOptions are the optional parts of a new report.
+type CreateReportOptions struct {
+	MediaIds []uuid.UUID
+	// Corroborates links the new report to an existing report instead of
+	// creating a new primary report
+	Corroborates uuid.UUID
+	// CheckDuplicates makes CreateReport fail with a DuplicateReportsError
+	// when the report looks like one that was already made
+	CheckDuplicates bool
+	// Anonymous reports never show who made them, only incident types that
+	// allow anonymity can be reported this way
+	Anonymous bool
+	// ClientID is the id the app gave a report it queued offline, a second
+	// report of the same user with the same id fails with
+	// infra.ErrReportClientIDExists
+	ClientID uuid.UUID
+	// OccurredAt is when the incident happened by the reporter's clock
+	OccurredAt time.Time
 }
 
 func (r *ReportService) CreateReport(
 	ctx context.Context,
 	incidentType, longitude, latitude, description string,
+	options CreateReportOptions,
 ) (domain.Report, error) {
-	incidentTypes := []string{"robbery", "fire", "accident", "cult"}
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Report{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	reporter, err := r.userRepo.GetUserByUserId(ctx, jwtClaims.ID)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if reporter.IsSuspended(time.Now()) {
+		return domain.Report{}, fmt.Errorf("%w until %s", ErrUserSuspended, reporter.SuspendedUntil.UTC().Format(time.RFC3339))
+	}
 
-	isIncidentTypeLegit := false
-	for _, element := range incidentTypes {
-		if element == incidentType {
-			isIncidentTypeLegit = true
+	activeIncidentType, err := r.incidentTypeService.GetActiveIncidentType(ctx, incidentType)
+	if err != nil {
+		if errors.Is(err, infra.ErrIncidentTypeNotFound) {
+			return domain.Report{}, ErrInvalidIncidentType
+		}
+		return domain.Report{}, err
+	}
+	if options.Anonymous {
+		if !activeIncidentType.AllowsAnonymous {
+			return domain.Report{}, ErrAnonymityNotAllowed
+		}
+		if err := r.checkAnonymousRateLimit(ctx, jwtClaims.ID); err != nil {
+			return domain.Report{}, err
 		}
 	}
 
-	if !isIncidentTypeLegit {
-		return domain.Report{}, ErrInvalidIncidentType
+	lat, lng, err := parseCoordinates(latitude, longitude)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if err := validateOccurredAt(options.OccurredAt, time.Now(), activeIncidentType.TimeToLive); err != nil {
+		return domain.Report{}, err
+	}
+	description, findings, err := r.contentFilter.Filter(description)
+	if err != nil {
+		return domain.Report{}, err
 	}
 
 	newReport := domain.Report{
-		ID:           uuid.New(),
-		IncidentType: incidentType,
-		Longitude:    longitude,
-		Latitude:     latitude,
-		Description:  description,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:               uuid.New(),
+		OwnerID:          jwtClaims.ID,
+		IncidentType:     incidentType,
+		Longitude:        longitude,
+		Latitude:         latitude,
+		Lat:              lat,
+		Lng:              lng,
+		Description:      description,
+		Status:           domain.ReportStatusReported,
+		CredibilityScore: initialCredibilityScore,
+		ClientID:         options.ClientID,
+		OccurredAt:       options.OccurredAt,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	newReport.StateCode, newReport.LGACode = r.geocoder.Locate(lat, lng)
+	if options.Anonymous {
+		newReport.OwnerID = uuid.Nil
+		newReport.IsAnonymous = true
 	}
 
-	err := r.reportRepo.CreateReport(ctx, newReport)
+	var primary domain.Report
+	if options.Corroborates != uuid.Nil {
+		primary, err = r.resolvePrimary(ctx, options.Corroborates)
+		if err != nil {
+			return domain.Report{}, err
+		}
+		newReport.DuplicateOf = primary.ID
+	} else if options.CheckDuplicates {
+		duplicates, err := r.findDuplicates(ctx, newReport)
+		if err != nil {
+			return domain.Report{}, err
+		}
+		if len(duplicates) > 0 {
+			return domain.Report{}, &DuplicateReportsError{Duplicates: duplicates}
+		}
+	}
+
+	media, err := r.resolveMedia(ctx, newReport, options.MediaIds)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	if newReport.IsAnonymous {
+		for i := range media {
+			media[i].OwnerID = uuid.Nil
+		}
+	}
+	// the media, content findings and the link to the primary report are
+	// stored with the report, so a failed write does not leave a report
+	// without its media, out of moderation or unlinked
+	newReport.Media = media
+	findings = ownContentFindings(newReport.ID, findings)
+	if newReport.IsAnonymous {
+		err = r.reportRepo.CreateAnonymousReport(ctx, newReport, jwtClaims.ID, findings)
+	} else {
+		err = r.reportRepo.CreateReport(ctx, newReport, findings)
+	}
 	if err != nil {
 		return domain.Report{}, err
 	}
+
+	if newReport.IsCorroboration() {
+		// watchers were already alerted about the primary report
+		if _, err := r.publishPrimary(ctx, primary.ID); err != nil {
+			return domain.Report{}, err
+		}
+		return newReport, nil
+	}
+
+	r.broadcaster.Publish(ctx, domain.ReportEventCreated, newReport)
+	r.alerter.NotifyNewReport(ctx, newReport)
 	return newReport, nil
 }
 
+func (r *ReportService) UpdateReport(
+	ctx context.Context,
+	reportId uuid.UUID,
+	incidentType, longitude, latitude, description string,
+) (domain.Report, error) {
+	existingReport, err := r.getOwnReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	if incidentType != existingReport.IncidentType {
+		activeIncidentType, err := r.incidentTypeService.GetActiveIncidentType(ctx, incidentType)
+		if err != nil {
+			if errors.Is(err, infra.ErrIncidentTypeNotFound) {
+				return domain.Report{}, ErrInvalidIncidentType
+			}
+			return domain.Report{}, err
+		}
+		if existingReport.IsAnonymous && !activeIncidentType.AllowsAnonymous {
+			return domain.Report{}, ErrAnonymityNotAllowed
+		}
+	}
+
+	lat, lng, err := parseCoordinates(latitude, longitude)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	description, findings, err := r.contentFilter.Filter(description)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	existingReport.IncidentType = incidentType
+	existingReport.Longitude = longitude
+	existingReport.Latitude = latitude
+	existingReport.Lat = lat
+	existingReport.Lng = lng
+	existingReport.StateCode, existingReport.LGACode = r.geocoder.Locate(lat, lng)
+	existingReport.Description = description
+	existingReport.UpdatedAt = time.Now()
+
+	err = r.reportRepo.UpdateReport(ctx, existingReport, ownContentFindings(existingReport.ID, findings))
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if err := r.loadMedia(ctx, &existingReport); err != nil {
+		return domain.Report{}, err
+	}
+	r.broadcaster.Publish(ctx, domain.ReportEventUpdated, existingReport)
+	return existingReport, nil
+}
+
+// DeleteReport only marks the report as deleted so it stays visible to
+// moderators.
+func (r *ReportService) DeleteReport(ctx context.Context, reportId uuid.UUID) error {
+	existingReport, err := r.getOwnReport(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	existingReport.DeletedAt = time.Now()
+	existingReport.UpdatedAt = existingReport.DeletedAt
+	err = r.reportRepo.UpdateReport(ctx, existingReport, []domain.ContentFinding{})
+	if err != nil {
+		return err
+	}
+	r.broadcaster.Publish(ctx, domain.ReportEventDeleted, existingReport)
+	return nil
+}
+
+func (r *ReportService) getOwnReport(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Report{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	existingReport, err := r.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	isOwner, err := r.isReportOwner(ctx, existingReport, jwtClaims.ID)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if !isOwner {
+		return domain.Report{}, ErrNotReportOwner
+	}
+	return existingReport, nil
+}
+
+// getVisibleReport fails with infra.ErrReportNotFound for deleted reports,
+// and for hidden reports unless the user is a moderator. Every action on a
+// report must load it through here.
+func (r *ReportService) getVisibleReport(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	existingReport, err := r.reportRepo.GetReportByReportId(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if !existingReport.IsVisible(isModerator(ctx)) {
+		return domain.Report{}, infra.ErrReportNotFound
+	}
+	return existingReport, nil
+}
+
+// GetReportByReportId lets moderators see deleted reports, nothing can be
+// done to them though.
 func (r *ReportService) GetReportByReportId(
 	ctx context.Context, reportId uuid.UUID,
 ) (domain.Report, error) {
@@ -64,27 +362,144 @@ func (r *ReportService) GetReportByReportId(
 	if err != nil {
 		return domain.Report{}, err
 	}
+	if !isModerator(ctx) && !existingReport.IsVisible(false) {
+		return domain.Report{}, infra.ErrReportNotFound
+	}
+	if err := r.loadMedia(ctx, &existingReport); err != nil {
+		return domain.Report{}, err
+	}
 	return existingReport, nil
 }
 
-func (r *ReportService) GetLatestReports(
+// GetReportsByUserId returns a page of the user's reports along with the
+// total number of reports matching the status filter.
+func (r *ReportService) GetReportsByUserId(
+	ctx context.Context, userId uuid.UUID, status string, pageNumber, rowsPerPage int,
+) ([]domain.Report, int, error) {
+	if status != "" && !IsValidStatus(status) {
+		return []domain.Report{}, 0, ErrInvalidStatus
+	}
+
+	reports, err := r.reportRepo.GetReportsByUserId(
+		ctx, userId, status, pageNumber, rowsPerPage)
+	if err != nil {
+		return []domain.Report{}, 0, err
+	}
+	total, err := r.reportRepo.CountReportsByUserId(ctx, userId, status)
+	if err != nil {
+		return []domain.Report{}, 0, err
+	}
+	if err := r.loadMedia(ctx, toReportPointers(reports)...); err != nil {
+		return []domain.Report{}, 0, err
+	}
+	return reports, total, nil
+}
+
+func (r *ReportService) GetNearbyReports(
 	ctx context.Context,
+	latitude, longitude, radiusInMeters float64,
+	incidentType string,
+	createdAfter, createdBefore time.Time,
 	pageNumber, rowsPerPage int,
-) ([]domain.Report, error) {
-	reports, err := r.reportRepo.GetLatestReports(ctx, pageNumber, rowsPerPage)
+) ([]domain.NearbyReport, error) {
+	if !geo.IsValidCoordinate(latitude, longitude) {
+		return []domain.NearbyReport{}, ErrInvalidLocation
+	}
+	// written so NaN is rejected too
+	if !(radiusInMeters >= 1 && radiusInMeters <= MaxNearbyRadiusInMeters) {
+		return []domain.NearbyReport{}, ErrInvalidRadius
+	}
+
+	query := infra.NearbyReportsQuery{
+		Latitude:       latitude,
+		Longitude:      longitude,
+		RadiusInMeters: radiusInMeters,
+		IncidentType:   incidentType,
+		CreatedAfter:   createdAfter,
+		CreatedBefore:  createdBefore,
+	}
+	reports, err := r.reportRepo.GetNearbyReports(ctx, query, pageNumber, rowsPerPage)
 	if err != nil {
-		return []domain.Report{}, err
+		return []domain.NearbyReport{}, err
+	}
+
+	pointers := []*domain.Report{}
+	for i := range reports {
+		pointers = append(pointers, &reports[i].Report)
+	}
+	if err := r.loadMedia(ctx, pointers...); err != nil {
+		return []domain.NearbyReport{}, err
 	}
 	return reports, nil
 }
 
-func (r *ReportService) GetReportsByUserId(
-	ctx context.Context, userId uuid.UUID, pageNumber, rowsPerPage int,
-) ([]domain.Report, error) {
-	reports, err := r.reportRepo.GetReportsByUserId(
-		ctx, userId, pageNumber, rowsPerPage)
+func (r *ReportService) GetMapView(
+	ctx context.Context, box geo.BoundingBox, zoom int,
+) (domain.MapView, error) {
+	if !IsValidBoundingBox(box) {
+		return domain.MapView{}, ErrInvalidBoundingBox
+	}
+	if zoom < 0 || zoom > MaxZoom {
+		return domain.MapView{}, ErrInvalidZoom
+	}
+
+	count := 0
+	if zoom < MinUnclusteredZoom {
+		var err error
+		count, err = r.reportRepo.CountReportsInBoundingBox(ctx, box)
+		if err != nil {
+			return domain.MapView{}, err
+		}
+	}
+
+	if zoom >= MinUnclusteredZoom || count <= MaxUnclusteredReports {
+		reports, err := r.reportRepo.GetReportsInBoundingBox(ctx, box, MaxUnclusteredReports)
+		if err != nil {
+			return domain.MapView{}, err
+		}
+		return domain.MapView{Reports: reports}, nil
+	}
+
+	// a web map tile spans 360/2^zoom degrees of longitude, each tile is split
+	// into a fixed number of cells so clusters keep a constant on-screen size
+	cellSizeInDegrees := 360 / math.Pow(2, float64(zoom)) / clusterCellsPerTile
+	clusters, err := r.reportRepo.GetReportClustersInBoundingBox(ctx, box, cellSizeInDegrees)
 	if err != nil {
-		return []domain.Report{}, err
+		return domain.MapView{}, err
 	}
-	return reports, nil
+	return domain.MapView{IsClustered: true, Clusters: clusters}, nil
+}
+
+func IsValidBoundingBox(box geo.BoundingBox) bool {
+	return geo.IsValidCoordinate(box.MinLatitude, box.MinLongitude) &&
+		geo.IsValidCoordinate(box.MaxLatitude, box.MaxLongitude) &&
+		box.MinLatitude <= box.MaxLatitude && box.MinLongitude <= box.MaxLongitude
+}
+
+func toReportPointers(reports []domain.Report) []*domain.Report {
+	result := []*domain.Report{}
+	for i := range reports {
+		result = append(result, &reports[i])
+	}
+	return result
+}
+
+func parseCoordinates(latitude, longitude string) (float64, float64, error) {
+	lat, err := strconv.ParseFloat(latitude, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidLocation
+	}
+	lng, err := strconv.ParseFloat(longitude, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidLocation
+	}
+	if !geo.IsValidCoordinate(lat, lng) {
+		return 0, 0, ErrInvalidLocation
+	}
+	return lat, lng, nil
+}
+
+func isModerator(ctx context.Context) bool {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	return ok && jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin)
 }
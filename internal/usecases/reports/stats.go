@@ -0,0 +1,135 @@
+package reports
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultStatsRange = 30 * 24 * time.Hour
+	MaxStatsRange     = 366 * 24 * time.Hour
+	// the area of the stats is split into statsCellsPerSide cells along its
+	// widest side
+	statsCellsPerSide = 10
+	// roughly a hundred meters
+	minStatsCellSizeInDegrees = 0.001
+	// stats of periods that ended a while ago no longer change, apart from
+	// the odd status change, so they are kept far longer than recent ones
+	settledStatsAge      = time.Hour
+	settledStatsCacheTTL = 6 * time.Hour
+	recentStatsCacheTTL  = 2 * time.Minute
+)
+
+var (
+	ErrInvalidTimeRange = fmt.Errorf("from must be before to and at most %d days earlier", int(MaxStatsRange.Hours()/24))
+	ErrInvalidTimeZone  = errors.New("invalid time_zone")
+)
+
+var worldBoundingBox = geo.BoundingBox{MinLatitude: -90, MinLongitude: -180, MaxLatitude: 90, MaxLongitude: 180}
+
+// GetReportStats defaults to the last DefaultStatsRange when no period is
+// given. Days and weeks are counted in timeZone, UTC when it is empty.
+func (r *ReportService) GetReportStats(ctx context.Context, query infra.ReportStatsQuery, timeZone string) (domain.ReportStats, error) {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return domain.ReportStats{}, ErrInvalidTimeZone
+	}
+	query.Location = location
+
+	if query.To.IsZero() {
+		query.To = time.Now().Truncate(time.Minute)
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-DefaultStatsRange)
+	}
+	query.From, query.To = query.From.UTC(), query.To.UTC()
+	if !query.From.Before(query.To) || query.To.Sub(query.From) > MaxStatsRange {
+		return domain.ReportStats{}, ErrInvalidTimeRange
+	}
+
+	box := worldBoundingBox
+	if query.Box != nil {
+		if !IsValidBoundingBox(*query.Box) {
+			return domain.ReportStats{}, ErrInvalidBoundingBox
+		}
+		box = *query.Box
+	}
+	query.CellSizeInDegrees = max(
+		(box.MaxLatitude-box.MinLatitude)/statsCellsPerSide,
+		(box.MaxLongitude-box.MinLongitude)/statsCellsPerSide,
+		minStatsCellSizeInDegrees,
+	)
+	sort.Strings(query.IncidentTypes)
+
+	key, err := reportStatsCacheKey(query)
+	if err != nil {
+		return domain.ReportStats{}, err
+	}
+	if cached, err := r.cache.GetOne(ctx, key); err == nil {
+		var stats domain.ReportStats
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			return inLocation(stats, location), nil
+		}
+	}
+
+	stats, err := r.reportRepo.GetReportStats(ctx, query)
+	if err != nil {
+		return domain.ReportStats{}, err
+	}
+
+	encoded, err := json.Marshal(stats)
+	if err != nil {
+		return domain.ReportStats{}, fmt.Errorf("error encoding report stats: %w", err)
+	}
+	ttl := recentStatsCacheTTL
+	if time.Since(query.To) > settledStatsAge {
+		ttl = settledStatsCacheTTL
+	}
+	// the stats are still good when they cannot be cached
+	if err := r.cache.SetOne(ctx, key, string(encoded), ttl); err != nil {
+		r.logger.Error("error caching report stats", zap.Error(err))
+	}
+	return stats, nil
+}
+
+func reportStatsCacheKey(query infra.ReportStatsQuery) (string, error) {
+	encoded, err := json.Marshal(struct {
+		Box           *geo.BoundingBox
+		IncidentTypes []string
+		From          time.Time
+		To            time.Time
+		TimeZone      string
+	}{query.Box, query.IncidentTypes, query.From, query.To, query.Location.String()})
+	if err != nil {
+		return "", fmt.Errorf("error encoding report stats query: %w", err)
+	}
+	sum := sha256.Sum256(encoded)
+	return "reports:stats:" + hex.EncodeToString(sum[:]), nil
+}
+
+// inLocation restores the time zone of cached stats, JSON only keeps the
+// offset.
+func inLocation(stats domain.ReportStats, location *time.Location) domain.ReportStats {
+	stats.From, stats.To, stats.PreviousFrom = stats.From.In(location), stats.To.In(location), stats.PreviousFrom.In(location)
+	for _, buckets := range [][]domain.TimeBucketCount{stats.ByDay, stats.ByWeek} {
+		for i := range buckets {
+			buckets[i].Start = buckets[i].Start.In(location)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,146 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+var (
+	ErrInvalidStatus           = errors.New("invalid status")
+	ErrInvalidStatusTransition = errors.New("report cannot move to the requested status")
+	ErrStatusChangeForbidden   = errors.New("you are not allowed to change the status of this report")
+	ErrInvalidToken            = errors.New("invalid token")
+)
+
+// reportStatusTransitions lists, for every status, the statuses a report can
+// move to next. resolved, false_alarm and expired are terminal.
+var reportStatusTransitions = map[string][]string{
+	domain.ReportStatusReported: {
+		domain.ReportStatusVerified,
+		domain.ReportStatusResolved,
+		domain.ReportStatusFalseAlarm,
+		domain.ReportStatusExpired,
+	},
+	domain.ReportStatusVerified: {
+		domain.ReportStatusResolved,
+		domain.ReportStatusFalseAlarm,
+		domain.ReportStatusExpired,
+	},
+	domain.ReportStatusResolved:   {},
+	domain.ReportStatusFalseAlarm: {},
+	domain.ReportStatusExpired:    {},
+}
+
+// statusesSetByOwner are the statuses a reporter may set on their own report,
+// every other transition needs a moderator. expired is only set by the system.
+var statusesSetByOwner = []string{
+	domain.ReportStatusResolved,
+	domain.ReportStatusFalseAlarm,
+}
+
+func IsValidStatus(status string) bool {
+	_, ok := reportStatusTransitions[status]
+	return ok
+}
+
+func canTransition(from, to string) bool {
+	for _, next := range reportStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func canChangeStatus(claims auth.JWTClaims, isOwner bool, to string) bool {
+	if to == domain.ReportStatusExpired {
+		return false
+	}
+	if claims.HasRole(domain.RoleModerator, domain.RoleAdmin) {
+		return true
+	}
+	if !isOwner {
+		return false
+	}
+	for _, status := range statusesSetByOwner {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReportService) ChangeReportStatus(
+	ctx context.Context, reportId uuid.UUID, status, note string,
+) (domain.Report, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Report{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	if !IsValidStatus(status) {
+		return domain.Report{}, ErrInvalidStatus
+	}
+
+	existingReport, err := r.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	if !canTransition(existingReport.Status, status) {
+		return domain.Report{}, ErrInvalidStatusTransition
+	}
+	isOwner, err := r.isReportOwner(ctx, existingReport, jwtClaims.ID)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if !canChangeStatus(jwtClaims, isOwner, status) {
+		return domain.Report{}, ErrStatusChangeForbidden
+	}
+
+	changedBy := jwtClaims.ID
+	if existingReport.IsAnonymous && !jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin) {
+		// the history must not give away who made an anonymous report
+		changedBy = uuid.Nil
+	}
+	return r.transitionReportStatus(ctx, existingReport, status, changedBy, note)
+}
+
+func (r *ReportService) transitionReportStatus(
+	ctx context.Context, report domain.Report, status string, changedBy uuid.UUID, note string,
+) (domain.Report, error) {
+	change := domain.ReportStatusChange{
+		ID:         uuid.New(),
+		ReportID:   report.ID,
+		FromStatus: report.Status,
+		ToStatus:   status,
+		ChangedBy:  changedBy,
+		Note:       note,
+		CreatedAt:  time.Now(),
+	}
+	err := r.reportRepo.UpdateReportStatus(ctx, change)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	report.Status = status
+	report.UpdatedAt = change.CreatedAt
+	r.broadcaster.Publish(ctx, domain.ReportEventUpdated, report)
+	return report, nil
+}
+
+func (r *ReportService) GetReportStatusHistory(
+	ctx context.Context, reportId uuid.UUID,
+) ([]domain.ReportStatusChange, error) {
+	history, err := r.reportRepo.GetReportStatusHistory(ctx, reportId)
+	if err != nil {
+		return []domain.ReportStatusChange{}, err
+	}
+	return history, nil
+}
@@ -0,0 +1,53 @@
+package reports
+
+import (
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/events"
+	"github.com/olad5/caution-companion/pkg/utils/geo"
+)
+
+// ReportEventFilter narrows a stream of report events, empty fields match
+// every report.
+type ReportEventFilter struct {
+	IncidentTypes []string
+	BoundingBox   *geo.BoundingBox
+}
+
+func (f ReportEventFilter) Matches(event domain.ReportEvent) bool {
+	if len(f.IncidentTypes) > 0 {
+		found := false
+		for _, incidentType := range f.IncidentTypes {
+			if incidentType == event.Report.IncidentType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.BoundingBox != nil && !f.BoundingBox.Contains(event.Report.Lat, event.Report.Lng) {
+		return false
+	}
+	return true
+}
+
+// SubscribeToReportEvents returns a live subscription to report events along
+// with the matching events missed since lastEventID. Live events still have
+// to be checked against the filter by the caller.
+func (r *ReportService) SubscribeToReportEvents(
+	filter ReportEventFilter, lastEventID string,
+) (*events.Subscription, []domain.ReportEvent, error) {
+	if filter.BoundingBox != nil && !IsValidBoundingBox(*filter.BoundingBox) {
+		return nil, []domain.ReportEvent{}, ErrInvalidBoundingBox
+	}
+
+	subscription, missed := r.broadcaster.Subscribe(lastEventID)
+	result := []domain.ReportEvent{}
+	for _, event := range missed {
+		if filter.Matches(event) {
+			result = append(result, event)
+		}
+	}
+	return subscription, result, nil
+}
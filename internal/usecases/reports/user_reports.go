@@ -0,0 +1,44 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+var ErrReportHistoryPrivate = errors.New("this user's report history is private")
+
+func (r *ReportService) GetMyReports(
+	ctx context.Context, status string, pageNumber, rowsPerPage int,
+) ([]domain.Report, int, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.Report{}, 0, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+	return r.GetReportsByUserId(ctx, jwtClaims.ID, status, pageNumber, rowsPerPage)
+}
+
+// GetReportsByUserName returns another user's report history. Users who have
+// hidden their history are only visible to themselves and to moderators.
+func (r *ReportService) GetReportsByUserName(
+	ctx context.Context, userName, status string, pageNumber, rowsPerPage int,
+) ([]domain.Report, int, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return []domain.Report{}, 0, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	user, err := r.userRepo.GetUserByUserName(ctx, userName)
+	if err != nil {
+		return []domain.Report{}, 0, err
+	}
+
+	if user.HideReports && user.ID != jwtClaims.ID &&
+		!jwtClaims.HasRole(domain.RoleModerator, domain.RoleAdmin) {
+		return []domain.Report{}, 0, ErrReportHistoryPrivate
+	}
+	return r.GetReportsByUserId(ctx, user.ID, status, pageNumber, rowsPerPage)
+}
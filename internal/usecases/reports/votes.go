@@ -0,0 +1,91 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/olad5/caution-companion/internal/domain"
+	"github.com/olad5/caution-companion/internal/services/auth"
+)
+
+var (
+	ErrInvalidVote           = errors.New("vote must be either confirm or dispute")
+	ErrCannotVoteOnOwnReport = errors.New("you cannot vote on your own report")
+)
+
+const initialCredibilityScore = 0.5
+
+func (r *ReportService) VoteOnReport(
+	ctx context.Context, reportId uuid.UUID, vote string,
+) (domain.Report, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Report{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	if vote != domain.VoteConfirm && vote != domain.VoteDispute {
+		return domain.Report{}, ErrInvalidVote
+	}
+
+	existingReport, err := r.getVisibleReport(ctx, reportId)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	isOwner, err := r.isReportOwner(ctx, existingReport, jwtClaims.ID)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	if isOwner {
+		return domain.Report{}, ErrCannotVoteOnOwnReport
+	}
+
+	err = r.reportRepo.CreateReportVote(ctx, domain.ReportVote{
+		ReportID:  reportId,
+		UserID:    jwtClaims.ID,
+		Vote:      vote,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	return r.reportRepo.GetReportByReportId(ctx, reportId)
+}
+
+func (r *ReportService) RetractVote(ctx context.Context, reportId uuid.UUID) (domain.Report, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.Report{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	if _, err := r.getVisibleReport(ctx, reportId); err != nil {
+		return domain.Report{}, err
+	}
+
+	err := r.reportRepo.DeleteReportVote(ctx, reportId, jwtClaims.ID)
+	if err != nil {
+		return domain.Report{}, err
+	}
+
+	return r.reportRepo.GetReportByReportId(ctx, reportId)
+}
+
+// GetUserVotes returns the vote the logged in user cast on each of the given
+// reports, reports the user has not voted on are left out.
+func (r *ReportService) GetUserVotes(
+	ctx context.Context, reports []domain.Report,
+) (map[uuid.UUID]string, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return map[uuid.UUID]string{}, nil
+	}
+
+	reportIds := []uuid.UUID{}
+	for _, report := range reports {
+		reportIds = append(reportIds, report.ID)
+	}
+	return r.reportRepo.GetUserVotes(ctx, jwtClaims.ID, reportIds)
+}
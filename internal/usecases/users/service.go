@@ -67,6 +67,7 @@ func (u *UserService) CreateUser(ctx context.Context, firstName, lastName, email
 		LastName:  strings.ToLower(lastName),
 		UserName:  createDefaultUserName(firstName, lastName),
 		Password:  hashedPassword,
+		Role:      domain.RoleUser,
 		CreatedAt: time.Now(),
 	}
 
@@ -111,6 +112,7 @@ func (u *UserService) EditUser(ctx context.Context, firstName, lastName, userNam
 		Password:  existingUser.Password,
 		Location:  location,
 		Phone:     phone,
+		Role:      existingUser.Role,
 		CreatedAt: existingUser.CreatedAt,
 		UpdatedAt: time.Now(),
 	}
@@ -163,6 +165,26 @@ func (u *UserService) GetLoggedInUser(ctx context.Context) (domain.User, error)
 	return existingUser, nil
 }
 
+func (u *UserService) UpdatePrivacy(ctx context.Context, hideReports bool) (domain.User, error) {
+	jwtClaims, ok := auth.GetJWTClaims(ctx)
+	if !ok {
+		return domain.User{}, fmt.Errorf("error parsing JWTClaims: %v", ErrInvalidToken)
+	}
+
+	existingUser, err := u.userRepo.GetUserByUserId(ctx, jwtClaims.ID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	existingUser.HideReports = hideReports
+	existingUser.UpdatedAt = time.Now()
+	err = u.userRepo.UpdateUser(ctx, existingUser)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return existingUser, nil
+}
+
 func (u *UserService) LogUserOut(ctx context.Context) error {
 	// TODO:TODO: this jwt check is duplicated multiple times, clean it up
 	jwtClaims, ok := auth.GetJWTClaims(ctx)
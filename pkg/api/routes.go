@@ -10,13 +10,31 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/olad5/caution-companion/config"
+	"github.com/olad5/caution-companion/internal/domain"
+	alertHandlers "github.com/olad5/caution-companion/internal/handlers/alerts"
 	authMiddleware "github.com/olad5/caution-companion/internal/handlers/auth"
+	commentHandlers "github.com/olad5/caution-companion/internal/handlers/comments"
 	fileHandlers "github.com/olad5/caution-companion/internal/handlers/files"
+	"github.com/olad5/caution-companion/internal/handlers/idempotency"
+	incidentTypesHandlers "github.com/olad5/caution-companion/internal/handlers/incidenttypes"
+	moderationHandlers "github.com/olad5/caution-companion/internal/handlers/moderation"
+	notificationHandlers "github.com/olad5/caution-companion/internal/handlers/notifications"
+	realtimeHandlers "github.com/olad5/caution-companion/internal/handlers/realtime"
 	reportsHandlers "github.com/olad5/caution-companion/internal/handlers/reports"
 	userHandlers "github.com/olad5/caution-companion/internal/handlers/users"
 	"github.com/olad5/caution-companion/internal/infra"
+	"github.com/olad5/caution-companion/internal/infra/webpush"
 	"github.com/olad5/caution-companion/internal/services/auth"
+	"github.com/olad5/caution-companion/internal/services/events"
+	"github.com/olad5/caution-companion/internal/services/geocode"
+	"github.com/olad5/caution-companion/internal/services/notify"
+	"github.com/olad5/caution-companion/internal/usecases/alerts"
+	"github.com/olad5/caution-companion/internal/usecases/comments"
 	"github.com/olad5/caution-companion/internal/usecases/files"
+	"github.com/olad5/caution-companion/internal/usecases/incidenttypes"
+	"github.com/olad5/caution-companion/internal/usecases/moderation"
+	"github.com/olad5/caution-companion/internal/usecases/notifications"
+	"github.com/olad5/caution-companion/internal/usecases/realtime"
 	"github.com/olad5/caution-companion/internal/usecases/reports"
 	"github.com/olad5/caution-companion/internal/usecases/users"
 	response "github.com/olad5/caution-companion/pkg/utils"
@@ -28,8 +46,17 @@ func NewHttpRouter(
 	ctx context.Context,
 	userRepo infra.UserRepository,
 	reportsRepo infra.ReportRepository,
+	incidentTypeRepo infra.IncidentTypeRepository,
+	commentRepo infra.CommentRepository,
+	fileRepo infra.FileRepository,
+	alertRepo infra.AlertSubscriptionRepository,
+	deviceRepo infra.DeviceRepository,
+	preferenceRepo infra.NotificationPreferenceRepository,
+	moderationRepo infra.ModerationRepository,
 	fileStore infra.FileStore,
 	cache infra.Cache,
+	broadcaster *events.Broadcaster,
+	dispatcher *notify.Dispatcher,
 	mailService infra.MailService,
 	configurations *config.Configurations,
 	l *zap.Logger,
@@ -48,7 +75,49 @@ func NewHttpRouter(
 	if err != nil {
 		log.Fatal("failed to create the User handler: ", err)
 	}
-	reportsService, err := reports.NewReportsService(reportsRepo)
+	incidentTypeService, err := incidenttypes.NewIncidentTypeService(incidentTypeRepo, cache, l)
+	if err != nil {
+		log.Fatal("Error Initializing IncidentTypeService")
+	}
+	incidentTypesHandler, err := incidentTypesHandlers.NewIncidentTypesHandler(*incidentTypeService, l)
+	if err != nil {
+		log.Fatal("failed to create the IncidentType handler: ", err)
+	}
+
+	webPushKey := ""
+	if configurations.VapidPrivateKey != "" {
+		webPushKey, err = webpush.DerivePublicKey(configurations.VapidPrivateKey)
+		if err != nil {
+			log.Fatal("Error Initializing web push key", err)
+		}
+	}
+	notificationService, err := notifications.NewNotificationService(deviceRepo, preferenceRepo, dispatcher, webPushKey)
+	if err != nil {
+		log.Fatal("Error Initializing NotificationService")
+	}
+	notificationsHandler, err := notificationHandlers.NewNotificationsHandler(*notificationService, l)
+	if err != nil {
+		log.Fatal("failed to create the Notification handler: ", err)
+	}
+
+	alertService, err := alerts.NewAlertService(alertRepo, userRepo, incidentTypeService, dispatcher, l)
+	if err != nil {
+		log.Fatal("Error Initializing AlertService")
+	}
+	alertsHandler, err := alertHandlers.NewAlertsHandler(*alertService, l)
+	if err != nil {
+		log.Fatal("failed to create the Alert handler: ", err)
+	}
+
+	geocoder, err := geocode.NewGeocoder(configurations.BoundariesFile)
+	if err != nil {
+		log.Fatal("Error Initializing Geocoder", err)
+	}
+	contentFilter, err := reports.NewDefaultContentFilter(configurations.ContentWordListFile)
+	if err != nil {
+		log.Fatal("Error Initializing ContentFilter", err)
+	}
+	reportsService, err := reports.NewReportsService(reportsRepo, incidentTypeService, fileRepo, userRepo, broadcaster, alertService, cache, geocoder, contentFilter, l)
 	if err != nil {
 		log.Fatal("Error Initializing UserService")
 	}
@@ -57,7 +126,34 @@ func NewHttpRouter(
 		log.Fatal("failed to create the Report handler: ", err)
 	}
 
-	filesService, err := files.NewFileService(fileStore)
+	moderationService, err := moderation.NewModerationService(moderationRepo, reportsRepo, userRepo, broadcaster)
+	if err != nil {
+		log.Fatal("Error Initializing ModerationService")
+	}
+	moderationHandler, err := moderationHandlers.NewModerationHandler(*moderationService, l)
+	if err != nil {
+		log.Fatal("failed to create the Moderation handler: ", err)
+	}
+
+	realtimeService, err := realtime.NewRealtimeService(reportsService, cache)
+	if err != nil {
+		log.Fatal("Error Initializing RealtimeService")
+	}
+	realtimeHandler, err := realtimeHandlers.NewRealtimeHandler(*realtimeService, l)
+	if err != nil {
+		log.Fatal("failed to create the Realtime handler: ", err)
+	}
+
+	commentService, err := comments.NewCommentService(commentRepo, reportsRepo)
+	if err != nil {
+		log.Fatal("Error Initializing CommentService")
+	}
+	commentsHandler, err := commentHandlers.NewCommentsHandler(*commentService, l)
+	if err != nil {
+		log.Fatal("failed to create the Comment handler: ", err)
+	}
+
+	filesService, err := files.NewFileService(fileStore, fileRepo)
 	if err != nil {
 		log.Fatal("Error Initializing FilesService")
 	}
@@ -66,13 +162,15 @@ func NewHttpRouter(
 		log.Fatal("failed to create the User handler: ", err)
 	}
 
+	idempotent := idempotency.Idempotent(cache, l)
+
 	router := chi.NewRouter()
 
 	// -------------------------------------------------------------------------
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", idempotency.HeaderName},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -112,12 +210,14 @@ func NewHttpRouter(
 			middleware.AllowContentType("application/json"),
 			middleware.SetHeader("Content-Type", "application/json"),
 		)
-		r.Post("/users", userHandler.CreateUser)
+		r.With(idempotent).Post("/users", userHandler.CreateUser)
 		r.Post("/users/login", userHandler.Login)
 		r.Post("/users/token/refresh", userHandler.RefreshAccessToken)
 		r.Post("/users/forgot-password", userHandler.ForgotPassword)
 		r.Post("/users/reset-password/verify-token", userHandler.VerifyResetPasswordToken)
 		r.Post("/users/reset-password", userHandler.ResetPassword)
+		r.Get("/incident-types", incidentTypesHandler.GetIncidentTypes)
+		r.Get("/notifications/web-push-key", notificationsHandler.GetWebPushKey)
 	})
 
 	// -------------------------------------------------------------------------
@@ -131,7 +231,29 @@ func NewHttpRouter(
 
 		r.Put("/users", userHandler.EditUser)
 		r.Get("/users/me", userHandler.GetLoggedInUser)
+		r.Put("/users/privacy", userHandler.UpdatePrivacy)
 		r.Put("/users/password", userHandler.ChangePassword)
+
+		r.Get("/users/me/alerts", alertsHandler.GetMyAlerts)
+		r.Post("/users/me/alerts", alertsHandler.CreateAlert)
+		r.Get("/users/me/alerts/{id}", alertsHandler.GetAlert)
+		r.Put("/users/me/alerts/{id}", alertsHandler.UpdateAlert)
+		r.Delete("/users/me/alerts/{id}", alertsHandler.DeleteAlert)
+
+		r.Get("/users/me/devices", notificationsHandler.GetMyDevices)
+		r.Post("/users/me/devices", notificationsHandler.RegisterDevice)
+		r.Delete("/users/me/devices/{id}", notificationsHandler.RemoveDevice)
+		r.Get("/users/me/notification-preferences", notificationsHandler.GetPreferences)
+		r.Put("/users/me/notification-preferences", notificationsHandler.UpdatePreferences)
+	})
+
+	// long lived connections, these do not respond with JSON
+	router.Group(func(r chi.Router) {
+		r.Use(authMiddleware.AccessTokenFromQuery("access_token"))
+		r.Use(authMiddleware.EnsureAuthenticated(authService))
+
+		r.Get("/reports/stream", reportsHandler.StreamReports)
+		r.Get("/ws", realtimeHandler.Connect)
 	})
 
 	router.Group(func(r chi.Router) {
@@ -141,16 +263,74 @@ func NewHttpRouter(
 		)
 		r.Use(authMiddleware.EnsureAuthenticated(authService))
 
-		r.Post("/reports", reportsHandler.CreateReport)
+		r.With(idempotent).Post("/reports", reportsHandler.CreateReport)
+		r.Post("/reports/batch", reportsHandler.CreateReportsBatch)
+		r.Get("/users/me/reports", reportsHandler.GetMyReports)
+		r.Get("/users/{user_name}/reports", reportsHandler.GetReportsByUserName)
 		r.Get("/reports/{id}", reportsHandler.GetReportByReportId)
+		r.Put("/reports/{id}", reportsHandler.UpdateReport)
+		r.Delete("/reports/{id}", reportsHandler.DeleteReport)
 		r.Get("/reports/latest", reportsHandler.GetLatestReports)
+		r.Get("/reports.geojson", reportsHandler.GetReportsGeoJSON)
+		r.Get("/reports/nearby", reportsHandler.GetNearbyReports)
+		r.Get("/reports/search", reportsHandler.SearchReports)
+		r.Get("/reports/map", reportsHandler.GetMapView)
+		r.Get("/reports/stats", reportsHandler.GetReportStats)
+		r.Post("/reports/{id}/status", reportsHandler.ChangeReportStatus)
+		r.Get("/reports/{id}/votes", reportsHandler.GetReportVotes)
+		r.Post("/reports/{id}/votes", reportsHandler.VoteOnReport)
+		r.Delete("/reports/{id}/votes", reportsHandler.RetractVote)
+		r.Post("/reports/{id}/media", reportsHandler.AttachMedia)
+		r.Get("/reports/{id}/corroborations", reportsHandler.GetCorroborations)
+		r.Get("/reports/{id}/links", reportsHandler.GetReportLinks)
+		r.Post("/reports/{id}/merge", reportsHandler.MergeReport)
+		r.Post("/reports/{id}/split", reportsHandler.SplitReport)
+		r.Post("/reports/{id}/flags", moderationHandler.FlagReport)
+
+		r.Get("/reports/{id}/comments", commentsHandler.GetComments)
+		r.Post("/reports/{id}/comments", commentsHandler.CreateComment)
+		r.Put("/reports/{id}/comments/{commentId}", commentsHandler.EditComment)
+		r.Delete("/reports/{id}/comments/{commentId}", commentsHandler.DeleteComment)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(
+			middleware.AllowContentType("application/json"),
+			middleware.SetHeader("Content-Type", "application/json"),
+		)
+		r.Use(authMiddleware.EnsureAuthenticated(authService))
+		r.Use(authMiddleware.EnsureRole(domain.RoleAdmin))
+
+		r.Get("/admin/incident-types", incidentTypesHandler.GetAllIncidentTypes)
+		r.Post("/admin/incident-types", incidentTypesHandler.CreateIncidentType)
+		r.Put("/admin/incident-types/{slug}", incidentTypesHandler.UpdateIncidentType)
+		r.Delete("/admin/incident-types/{slug}", incidentTypesHandler.RetireIncidentType)
+		r.Get("/admin/reports/{id}/owner", reportsHandler.GetAnonymousReportOwner)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(
+			middleware.AllowContentType("application/json"),
+			middleware.SetHeader("Content-Type", "application/json"),
+		)
+		r.Use(authMiddleware.EnsureAuthenticated(authService))
+		r.Use(authMiddleware.EnsureRole(domain.RoleModerator, domain.RoleAdmin))
+
+		r.Get("/moderation/queue", moderationHandler.GetModerationQueue)
+		r.Post("/moderation/reports/{id}/hide", moderationHandler.HideReport)
+		r.Post("/moderation/reports/{id}/restore", moderationHandler.RestoreReport)
+		r.Post("/moderation/reports/{id}/delete", moderationHandler.DeleteReport)
+		r.Post("/moderation/reports/{id}/warn", moderationHandler.WarnReporter)
+		r.Post("/moderation/reports/{id}/suspend", moderationHandler.SuspendReporter)
+		r.Get("/moderation/reports/{id}/actions", moderationHandler.GetModerationActions)
+		r.Get("/moderation/reports/{id}/content-findings", moderationHandler.GetContentFindings)
 	})
 
 	router.Group(func(r chi.Router) {
 		r.Use(middleware.AllowContentType("multipart/form-data"))
 		r.Use(authMiddleware.EnsureAuthenticated(authService))
 
-		r.Post("/files/upload", filesHandler.Upload)
+		r.With(idempotent).Post("/files/upload", filesHandler.Upload)
 	})
 
 	return router
@@ -3,6 +3,7 @@ package errors
 const (
 	ErrSomethingWentWrong = "something went wrong"
 	ErrUnauthorized       = "unauthorized"
+	ErrForbidden          = "forbidden"
 	ErrInvalidJson        = "Invalid JSON"
 	ErrMissingBody        = "missing body request"
 	ErrInvalidID          = "ID is not in its proper form"
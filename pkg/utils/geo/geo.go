@@ -0,0 +1,98 @@
+package geo
+
+import "math"
+
+const EarthRadiusInMeters = 6371000.0
+
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Distance returns the great-circle distance in meters between two points
+// using the haversine formula.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusInMeters * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBoxAround returns the smallest box that contains a circle of the
+// given radius, it is used to prefilter rows before computing exact distances.
+func BoundingBoxAround(lat, lng, radiusInMeters float64) BoundingBox {
+	latDelta := toDegrees(radiusInMeters / EarthRadiusInMeters)
+
+	lngDelta := 180.0
+	if cos := math.Cos(toRadians(lat)); cos > 1e-9 {
+		lngDelta = math.Min(180, latDelta/cos)
+	}
+
+	return BoundingBox{
+		MinLatitude:  math.Max(-90, lat-latDelta),
+		MinLongitude: math.Max(-180, lng-lngDelta),
+		MaxLatitude:  math.Min(90, lat+latDelta),
+		MaxLongitude: math.Min(180, lng+lngDelta),
+	}
+}
+
+func (b BoundingBox) Contains(lat, lng float64) bool {
+	return lat >= b.MinLatitude && lat <= b.MaxLatitude &&
+		lng >= b.MinLongitude && lng <= b.MaxLongitude
+}
+
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// PolygonContains reports whether the point is inside the polygon using ray
+// casting, the polygon is closed implicitly. Polygons are assumed to be
+// small enough that treating coordinates as planar is accurate.
+func PolygonContains(polygon []Point, lat, lng float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > lat) != (b.Latitude > lat) &&
+			lng < (b.Longitude-a.Longitude)*(lat-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// BoundingBoxOf returns the smallest box that contains every point.
+func BoundingBoxOf(points []Point) BoundingBox {
+	if len(points) == 0 {
+		return BoundingBox{}
+	}
+	box := BoundingBox{
+		MinLatitude:  points[0].Latitude,
+		MinLongitude: points[0].Longitude,
+		MaxLatitude:  points[0].Latitude,
+		MaxLongitude: points[0].Longitude,
+	}
+	for _, point := range points[1:] {
+		box.MinLatitude = math.Min(box.MinLatitude, point.Latitude)
+		box.MinLongitude = math.Min(box.MinLongitude, point.Longitude)
+		box.MaxLatitude = math.Max(box.MaxLatitude, point.Latitude)
+		box.MaxLongitude = math.Max(box.MaxLongitude, point.Longitude)
+	}
+	return box
+}
+
+func IsValidCoordinate(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -37,6 +37,20 @@ func ErrorResponse(w http.ResponseWriter, message string, statusCode int) {
 	}
 }
 
+// ErrorResponseWithData is an ErrorResponse that also tells the client
+// what it can do about the error.
+func ErrorResponseWithData(w http.ResponseWriter, message string, data interface{}, statusCode int) {
+	type ErrorResponse struct {
+		Status  bool        `json:"status"`
+		Message string      `json:"message"`
+		Data    interface{} `json:"data,omitempty"`
+	}
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(ErrorResponse{Status: false, Message: message, Data: data}); err != nil {
+		log.Printf("Error sending response: %v", err)
+	}
+}
+
 func InternalServerErrorResponse(w http.ResponseWriter, err error, l *zap.Logger) {
 	l.Error("[INTERNAL_SERVER_ERR]", zap.Error(err))
 	ErrorResponse(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError)
@@ -4,29 +4,49 @@
 package integration
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/olad5/caution-companion/config"
 	"github.com/olad5/caution-companion/config/data"
+	"github.com/olad5/caution-companion/internal/domain"
 	"github.com/olad5/caution-companion/internal/infra/cloudinary"
+	"github.com/olad5/caution-companion/internal/infra/lognotifier"
 	"github.com/olad5/caution-companion/internal/infra/postgres"
 	"github.com/olad5/caution-companion/internal/infra/redis"
 	"github.com/olad5/caution-companion/internal/infra/smtpexpress"
+	"github.com/olad5/caution-companion/internal/services/events"
+	"github.com/olad5/caution-companion/internal/services/geocode"
+	"github.com/olad5/caution-companion/internal/services/jobs"
+	"github.com/olad5/caution-companion/internal/services/notify"
+	"github.com/olad5/caution-companion/internal/usecases/reports"
 	"github.com/olad5/caution-companion/pkg/api"
 	"github.com/olad5/caution-companion/pkg/utils/logger"
 	"github.com/olad5/caution-companion/tests"
+	"golang.org/x/net/websocket"
 )
 
 var (
-	appRouter      http.Handler
-	configurations *config.Configurations
+	appRouter          http.Handler
+	configurations     *config.Configurations
+	postgresConnection *sqlx.DB
+	// notificationLogFile receives every push notification sent in tests
+	notificationLogFile string
+	// reportExpiryJob is run by the tests instead of being scheduled
+	reportExpiryJob jobs.Job
 )
 
 var (
@@ -43,7 +63,7 @@ func TestMain(m *testing.M) {
 	ctx := context.Background()
 	l := logger.Get(configurations)
 
-	postgresConnection := data.StartPostgres(configurations.DatabaseUrl, l)
+	postgresConnection = data.StartPostgres(configurations.DatabaseUrl, l)
 	if err := postgres.Migrate(ctx, postgresConnection); err != nil {
 		log.Fatal("Error Migrating postgres", err)
 	}
@@ -59,6 +79,41 @@ func TestMain(m *testing.M) {
 		log.Fatal("Error Initializing Reports Repo", err)
 	}
 
+	incidentTypeRepo, err := postgres.NewPostgresIncidentTypeRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Incident Types Repo", err)
+	}
+
+	commentRepo, err := postgres.NewPostgresCommentRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Comments Repo", err)
+	}
+
+	fileRepo, err := postgres.NewPostgresFileRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Files Repo", err)
+	}
+
+	alertRepo, err := postgres.NewPostgresAlertSubscriptionRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Alert Subscriptions Repo", err)
+	}
+
+	deviceRepo, err := postgres.NewPostgresDeviceRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Devices Repo", err)
+	}
+
+	preferenceRepo, err := postgres.NewPostgresNotificationPreferenceRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Notification Preferences Repo", err)
+	}
+
+	moderationRepo, err := postgres.NewPostgresModerationRepo(ctx, postgresConnection)
+	if err != nil {
+		log.Fatal("Error Initializing Moderation Repo", err)
+	}
+
 	redisCache, err := redis.New(ctx, configurations)
 	if err != nil {
 		log.Fatal("Error Initializing redisCache", err)
@@ -72,12 +127,71 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatal("Error Initializing smtpexpress mailservice", err)
 	}
+
+	broadcaster, err := events.NewBroadcaster(redisCache, l)
+	if err != nil {
+		log.Fatal("Error Initializing broadcaster", err)
+	}
+	if err := broadcaster.Start(ctx); err != nil {
+		log.Fatal("Error Starting broadcaster", err)
+	}
+	notificationLog, err := os.CreateTemp("", "notifications-*.jsonl")
+	if err != nil {
+		log.Fatal("Error Creating notification log", err)
+	}
+	notificationLog.Close()
+	notificationLogFile = notificationLog.Name()
+	defer os.Remove(notificationLogFile)
+
+	mailNotifier, err := notify.NewMailNotifier(mailService)
+	if err != nil {
+		log.Fatal("Error Initializing mail notifier", err)
+	}
+	pushNotifier, err := lognotifier.New(domain.NotificationChannelPush, notificationLogFile)
+	if err != nil {
+		log.Fatal("Error Initializing log notifier", err)
+	}
+	defer pushNotifier.Close()
+	dispatcher, err := notify.NewDispatcher(deviceRepo, preferenceRepo, mailNotifier, pushNotifier)
+	if err != nil {
+		log.Fatal("Error Initializing notification dispatcher", err)
+	}
+
+	wordLists, err := os.CreateTemp("", "word-lists-*.json")
+	if err != nil {
+		log.Fatal("Error Creating word lists", err)
+	}
+	_, err = wordLists.WriteString(`{"lists": [
+    {"language": "pcm", "action": "reject", "words": ["wahala word"]},
+    {"language": "yo", "action": "flag", "words": ["ọ̀rọ̀ burúkú"]}
+  ]}`)
+	wordLists.Close()
+	if err != nil {
+		log.Fatal("Error Writing word lists", err)
+	}
+	configurations.ContentWordListFile = wordLists.Name()
+	defer os.Remove(configurations.ContentWordListFile)
+
+	reportExpiryJob, err = jobs.NewReportExpiryJob(reportsRepo, broadcaster)
+	if err != nil {
+		log.Fatal("Error Initializing report expiry job", err)
+	}
+
 	appRouter = api.NewHttpRouter(
 		ctx,
 		userRepo,
 		reportsRepo,
+		incidentTypeRepo,
+		commentRepo,
+		fileRepo,
+		alertRepo,
+		deviceRepo,
+		preferenceRepo,
+		moderationRepo,
 		fileStore,
 		redisCache,
+		broadcaster,
+		dispatcher,
 		mailService,
 		configurations,
 		l)
@@ -463,8 +577,10 @@ func TestGetLatestReports(t *testing.T) {
 			token, _ := logUserIn(t, userEmail, userPassword)
 
 			const numberOfReports = 2
-			const pageToRetrieve = 1
-			req, _ := http.NewRequest(http.MethodGet, route+"/latest"+"?page="+fmt.Sprintf("%d", pageToRetrieve)+"&rows="+fmt.Sprintf("%d", numberOfReports), nil)
+			createReport(t, token, "fire", "3.3792", "6.5244", "first feed report")
+			createReport(t, token, "fire", "3.3792", "6.5244", "second feed report")
+			createReport(t, token, "fire", "3.3792", "6.5244", "third feed report")
+			req, _ := http.NewRequest(http.MethodGet, route+"/latest"+"?rows="+fmt.Sprintf("%d", numberOfReports), nil)
 			req.Header.Set("Authorization", "Bearer "+token)
 			response := tests.ExecuteRequest(req, appRouter)
 
@@ -475,7 +591,6 @@ func TestGetLatestReports(t *testing.T) {
 
 			data := responseBody["data"].(map[string]interface{})
 			reports := data["items"].([]interface{})
-			page := data["page"].(float64)
 			rows := data["rows"].(float64)
 			if len(reports) != numberOfReports {
 				t.Errorf("got items length: %d expected: %d", len(reports), numberOfReports)
@@ -483,33 +598,2227 @@ func TestGetLatestReports(t *testing.T) {
 			if rows != numberOfReports {
 				t.Errorf("got rows number retrieved: %v expected: %d", rows, numberOfReports)
 			}
-			if page != pageToRetrieve {
-				t.Errorf("got rows number retrieved: %v expected: %d", page, pageToRetrieve)
+			if description := reports[0].(map[string]interface{})["description"]; description != "third feed report" {
+				t.Errorf("got first report: %v expected the most recent report", description)
+			}
+
+			nextCursor, ok := data["next_cursor"].(string)
+			if !ok {
+				t.Fatalf("expected a next_cursor")
+			}
+			req, _ = http.NewRequest(http.MethodGet, route+"/latest"+"?rows=1&cursor="+nextCursor, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			reports = data["items"].([]interface{})
+			if description := reports[0].(map[string]interface{})["description"]; description != "first feed report" {
+				t.Errorf("got report after cursor: %v expected: %s", description, "first feed report")
 			}
 		},
 	)
+	t.Run("test for a cursor issued for a different sort", func(t *testing.T) {
+		token, _ := logUserIn(t, userEmail, userPassword)
+		createReport(t, token, "fire", "3.3792", "6.5244", "feed report")
+		createReport(t, token, "fire", "3.3792", "6.5244", "feed report")
+
+		req, _ := http.NewRequest(http.MethodGet, route+"/latest?rows=1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusOK, response.Code)
+		nextCursor := tests.ParseResponse(t, response)["data"].(map[string]interface{})["next_cursor"].(string)
+
+		req, _ = http.NewRequest(http.MethodGet, route+"/latest?rows=1&sort=oldest&cursor="+nextCursor, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response = tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+	})
 }
 
-func createReport(t testing.TB, token, incidentType, longitude, latitude, description string) string {
-	t.Helper()
-	route := "/reports"
-	requestBody := []byte(fmt.Sprintf(`{
+func TestGetNearbyReports(t *testing.T) {
+	route := "/reports/nearby"
+	t.Run("test for missing coordinates in query params",
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			req, _ := http.NewRequest(http.MethodGet, route, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+	t.Run("test for a radius or coordinates that are not finite numbers",
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			for _, query := range []string{"lat=6.5244&lng=3.3790&radius=NaN", "lat=6.5244&lng=3.3790&radius=Inf", "lat=NaN&lng=3.3790"} {
+				req, _ := http.NewRequest(http.MethodGet, route+"?"+query, nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+				response := tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+			}
+		},
+	)
+	t.Run(`Given a user wants to see reports around them, when they provide their 
+    coordinates and a radius, they receive only the reports within that radius 
+    sorted by distance, with the distance included in each report.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			nearId := createReport(t, token, "robbery", "3.3792", "6.5244", "close by")
+			farId := createReport(t, token, "robbery", "3.4500", "6.5244", "far away")
+
+			req, _ := http.NewRequest(http.MethodGet, route+"?lat=6.5244&lng=3.3790&radius=2000&incident_type=robbery&rows=100", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			responseBody := tests.ParseResponse(t, response)
+			message := responseBody["message"].(string)
+			tests.AssertResponseMessage(t, message, "nearby reports retrieved successfully")
+
+			data := responseBody["data"].(map[string]interface{})
+			items := data["items"].([]interface{})
+			foundNear := false
+			previousDistance := 0.0
+			for _, element := range items {
+				item := element.(map[string]interface{})
+				distance := item["distance_in_meters"].(float64)
+				if distance > 2000 || distance < previousDistance {
+					t.Errorf("got unexpected distance ordering: %v after %v", distance, previousDistance)
+				}
+				previousDistance = distance
+				if item["id"].(string) == farId {
+					t.Error("report outside the radius was returned")
+				}
+				if item["id"].(string) == nearId {
+					foundNear = true
+				}
+			}
+			if !foundNear {
+				t.Error("report within the radius was not returned")
+			}
+		},
+	)
+}
+
+func TestGetMapView(t *testing.T) {
+	route := "/reports/map"
+	// seedReports fills a small area with more reports than the map shows
+	// one by one, alternating between fires and accidents
+	seedReports := func(t *testing.T, minLat, minLng float64) {
+		email := "seeder" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+		ownerId := createUser(t, "seeder", "user", email, userPassword)
+		_, err := postgresConnection.Exec(`
+      INSERT INTO reports (id, owner_id, incident_type, longitude, latitude, lat, lng, description, created_at, updated_at)
+      SELECT gen_random_uuid(), $1,
+        CASE WHEN n % 2 = 0 THEN 'fire' ELSE 'accident' END,
+        ($3 + (n / 20) * 0.001)::TEXT, ($2 + (n % 20) * 0.001)::TEXT,
+        $2 + (n % 20) * 0.001, $3 + (n / 20) * 0.001,
+        'seeded for the map', NOW(), NOW()
+      FROM generate_series(0, $4 - 1) AS n`,
+			ownerId, minLat, minLng, reports.MaxUnclusteredReports+2)
+		if err != nil {
+			t.Fatalf("Unable to seed reports: %v", err)
+		}
+	}
+	getClusters := func(t *testing.T, query string) []interface{} {
+		token, _ := logUserIn(t, userEmail, userPassword)
+		req, _ := http.NewRequest(http.MethodGet, route+"?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusOK, response.Code)
+		data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+		tests.AssertResponseMessage(t, data["mode"].(string), "clusters")
+		if reports := data["reports"].([]interface{}); len(reports) != 0 {
+			t.Errorf("expected no individual reports in cluster mode, got %d", len(reports))
+		}
+		return data["clusters"].([]interface{})
+	}
+	t.Run("test for an invalid bounding box",
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			req, _ := http.NewRequest(http.MethodGet, route+"?min_lat=7&min_lng=3&max_lat=6&max_lng=4&zoom=12", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+	t.Run(`Given a user views a zoomed in area of the map, when they request the 
+    viewport, they receive the individual reports within the bounding box.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "accident", "3.3850", "6.4550", "in viewport")
+
+			req, _ := http.NewRequest(http.MethodGet, route+"?min_lat=6.4549&min_lng=3.3849&max_lat=6.4551&max_lng=3.3851&zoom=18", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			responseBody := tests.ParseResponse(t, response)
+			data := responseBody["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["mode"].(string), "reports")
+
+			found := false
+			for _, element := range data["reports"].([]interface{}) {
+				if element.(map[string]interface{})["id"].(string) == reportId {
+					found = true
+				}
+			}
+			if !found {
+				t.Error("report within the viewport was not returned")
+			}
+		},
+	)
+	t.Run(`Given a zoomed out viewport holding more reports than can be shown one 
+    by one, when the user requests the viewport, they receive clusters with a 
+    count and a centroid inside the bounding box.
+    `,
+		func(t *testing.T) {
+			seedReports(t, -40, -20)
+			clusters := getClusters(t, "min_lat=-40.01&min_lng=-20.01&max_lat=-39.97&max_lng=-19.98&zoom=10")
+
+			total := 0
+			for _, element := range clusters {
+				cluster := element.(map[string]interface{})
+				total += int(cluster["count"].(float64))
+				centroid := cluster["centroid"].(map[string]interface{})
+				latitude, longitude := centroid["latitude"].(float64), centroid["longitude"].(float64)
+				if latitude < -40.01 || latitude > -39.97 || longitude < -20.01 || longitude > -19.98 {
+					t.Errorf("centroid %v is outside the viewport", centroid)
+				}
+			}
+			if total <= reports.MaxUnclusteredReports {
+				t.Errorf("expected more than %d reports in the clusters, got %d", reports.MaxUnclusteredReports, total)
+			}
+		},
+	)
+	t.Run(`Given a clustered viewport with reports of several incident types, 
+    when the user requests the viewport, each cluster breaks its count down by 
+    incident type.
+    `,
+		func(t *testing.T) {
+			seedReports(t, -41, -21)
+			clusters := getClusters(t, "min_lat=-41.01&min_lng=-21.01&max_lat=-40.97&max_lng=-20.98&zoom=10")
+
+			totals := map[string]int{}
+			for _, element := range clusters {
+				cluster := element.(map[string]interface{})
+				breakdown := 0
+				for incidentType, count := range cluster["incident_types"].(map[string]interface{}) {
+					totals[incidentType] += int(count.(float64))
+					breakdown += int(count.(float64))
+				}
+				if breakdown != int(cluster["count"].(float64)) {
+					t.Errorf("got incident type breakdown: %d expected: %v", breakdown, cluster["count"])
+				}
+			}
+			half := (reports.MaxUnclusteredReports + 2) / 2
+			if totals["fire"] < half || totals["accident"] < half {
+				t.Errorf("expected at least %d fires and %d accidents, got %v", half, half, totals)
+			}
+		},
+	)
+}
+
+func TestIncidentTypes(t *testing.T) {
+	route := "/incident-types"
+	t.Run(`Given a client needs the list of incident types, when they make the 
+    request, they receive the active incident types.
+    `,
+		func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, route, nil)
+			response := tests.ExecuteRequest(req, appRouter)
+
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			responseBody := tests.ParseResponse(t, response)
+			message := responseBody["message"].(string)
+			tests.AssertResponseMessage(t, message, "incident types retrieved successfully")
+			if len(responseBody["data"].([]interface{})) == 0 {
+				t.Error("expected the default incident types to be returned")
+			}
+		},
+	)
+	t.Run(`Given a user who is not an admin tries to create an incident type, 
+    when they submit the request, they receive a forbidden response.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			requestBody := []byte(`{
+      "slug": "flood",
+      "display_name": "Flood",
+      "default_severity": 3
+      }`)
+			req, _ := http.NewRequest(http.MethodPost, "/admin"+route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+		},
+	)
+	t.Run(`Given an admin creates a new incident type, when a user files a report 
+    with it, the report is accepted, and once the admin retires the type new 
+    reports with it are rejected.
+    `,
+		func(t *testing.T) {
+			email := "admin" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "admin", "user", email, adminPassword)
+			promoteUser(t, email, "admin")
+			adminToken, _ := logUserIn(t, email, adminPassword)
+
+			slug := "flood_" + fmt.Sprint(tests.GenerateUniqueId())
+			requestBody := []byte(fmt.Sprintf(`{
+      "slug": "%s",
+      "display_name": "Flood",
+      "default_severity": 3,
+      "icon": "flood"
+      }`, slug))
+			req, _ := http.NewRequest(http.MethodPost, "/admin"+route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodPost, "/admin"+route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusConflict, response.Code)
+
+			token, _ := logUserIn(t, userEmail, userPassword)
+			createReport(t, token, slug, "3.3792", "6.5244", "water everywhere")
+
+			req, _ = http.NewRequest(http.MethodDelete, "/admin"+route+"/"+slug, nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			reportRequestBody := []byte(fmt.Sprintf(`{
       "incident_type": "%s",
       "location": {
-        "longitude": "%s",
-        "latitude": "%s"
+        "longitude": "3.3792",
+        "latitude": "6.5244"
         },
-      "description": "%s"
-      }`, incidentType, longitude, latitude, description))
-	req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
-	req.Header.Set("Authorization", "Bearer "+token)
+      "description": "water everywhere"
+      }`, slug))
+			req, _ = http.NewRequest(http.MethodPost, "/reports", bytes.NewBuffer(reportRequestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+	t.Run(`Given an incident type that allows anonymous reports and has a time to 
+    live, when an admin updates it without allows_anonymous or ttl_hours, both 
+    are kept.
+    `,
+		func(t *testing.T) {
+			email := "admin" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "admin", "user", email, adminPassword)
+			promoteUser(t, email, "admin")
+			adminToken, _ := logUserIn(t, email, adminPassword)
 
-	response := tests.ExecuteRequest(req, appRouter)
-	responseBody := tests.ParseResponse(t, response)
-	data := responseBody["data"].(map[string]interface{})
-	reportId := data["id"].(string)
+			slug := "stalking_" + fmt.Sprint(tests.GenerateUniqueId())
+			requestBody := []byte(fmt.Sprintf(`{
+      "slug": "%s",
+      "display_name": "Stalking",
+      "default_severity": 3,
+      "allows_anonymous": true,
+      "ttl_hours": 48
+      }`, slug))
+			req, _ := http.NewRequest(http.MethodPost, "/admin"+route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
 
-	return reportId
+			requestBody = []byte(`{
+      "display_name": "Stalking or harassment",
+      "default_severity": 4,
+      "is_active": true
+      }`)
+			req, _ = http.NewRequest(http.MethodPut, "/admin"+route+"/"+slug, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["display_name"].(string), "Stalking or harassment")
+			if data["allows_anonymous"] != true {
+				t.Errorf("expected allows_anonymous to be kept, got %v", data["allows_anonymous"])
+			}
+			if data["ttl_hours"].(float64) != 48 {
+				t.Errorf("got ttl_hours: %v expected: %d", data["ttl_hours"], 48)
+			}
+		},
+	)
+}
+
+func TestChangeReportStatus(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a user who is not a moderator tries to verify a report, when they 
+    submit the request, they receive a forbidden response.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "fire", "3.3792", "6.5244", "still burning")
+
+			requestBody := []byte(`{"status": "verified"}`)
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/status", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+		},
+	)
+	t.Run(`Given a moderator verifies and then resolves a report, when the report 
+    is retrieved, it carries the latest status and the history of the changes.
+    `,
+		func(t *testing.T) {
+			email := "moderator" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "moderator", "user", email, adminPassword)
+			promoteUser(t, email, "moderator")
+			moderatorToken, _ := logUserIn(t, email, adminPassword)
+			reportId := createReport(t, moderatorToken, "fire", "3.3792", "6.5244", "still burning")
+
+			for _, status := range []string{"verified", "resolved"} {
+				requestBody := []byte(fmt.Sprintf(`{"status": "%s", "note": "checked"}`, status))
+				req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/status", bytes.NewBuffer(requestBody))
+				req.Header.Set("Authorization", "Bearer "+moderatorToken)
+				response := tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			}
+
+			requestBody := []byte(`{"status": "verified"}`)
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/status", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["status"].(string), "resolved")
+			history := data["status_history"].([]interface{})
+			if len(history) != 2 {
+				t.Fatalf("got status history length: %d expected: %d", len(history), 2)
+			}
+			if history[0].(map[string]interface{})["changed_by"] == nil {
+				t.Errorf("expected the moderator to see who changed the status")
+			}
+
+			token, _ := logUserIn(t, userEmail, userPassword)
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			for _, element := range data["status_history"].([]interface{}) {
+				if changedBy := element.(map[string]interface{})["changed_by"]; changedBy != nil {
+					t.Errorf("expected changed_by to be hidden from other users, got %v", changedBy)
+				}
+			}
+		},
+	)
+}
+
+func TestReportVotes(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a user confirms a report, when they check the report, the 
+    confirmation is counted and their own vote is returned, and a second vote 
+    from the same user is rejected.
+    `,
+		func(t *testing.T) {
+			ownerEmail := "owner" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "owner", "user", ownerEmail, userPassword)
+			ownerToken, _ := logUserIn(t, ownerEmail, userPassword)
+			reportId := createReport(t, ownerToken, "accident", "3.3792", "6.5244", "two cars")
+
+			token, _ := logUserIn(t, userEmail, userPassword)
+			requestBody := []byte(`{"vote": "confirm"}`)
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/votes", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/votes", bytes.NewBuffer([]byte(`{"vote": "dispute"}`)))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusConflict, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["confirmations"].(float64) != 1 {
+				t.Errorf("got confirmations: %v expected: %d", data["confirmations"], 1)
+			}
+			tests.AssertResponseMessage(t, data["my_vote"].(string), "confirm")
+			if data["credibility_score"].(float64) <= 0.5 {
+				t.Errorf("expected credibility score to increase, got %v", data["credibility_score"])
+			}
+		},
+	)
+}
+
+func TestReportComments(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a user comments on a report and another user replies, when the 
+    comments are listed, the reply is nested under the comment, and only the 
+    author can edit the comment.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "fire", "3.3792", "6.5244", "market fire")
+
+			requestBody := []byte(`{"body": "fire service has arrived"}`)
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/comments", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			commentId := tests.ParseResponse(t, response)["data"].(map[string]interface{})["id"].(string)
+
+			email := "replier" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "reply", "user", email, userPassword)
+			otherToken, _ := logUserIn(t, email, userPassword)
+
+			requestBody = []byte(fmt.Sprintf(`{"body": "thank God", "parent_id": "%s"}`, commentId))
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/comments", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			requestBody = []byte(`{"body": "edited by someone else"}`)
+			req, _ = http.NewRequest(http.MethodPut, route+"/"+reportId+"/comments/"+commentId, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId+"/comments?page=1&rows=10", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			items := data["items"].([]interface{})
+			if len(items) != 1 {
+				t.Fatalf("got comments length: %d expected: %d", len(items), 1)
+			}
+			replies := items[0].(map[string]interface{})["replies"].([]interface{})
+			if len(replies) != 1 {
+				t.Errorf("got replies length: %d expected: %d", len(replies), 1)
+			}
+		},
+	)
+}
+
+func TestAttachReportMedia(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a user has uploaded a file, when they attach it to a report, 
+    the report is returned with the media, and another user cannot attach the 
+    same file.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			userId := getCurrentUser(t, token)["id"].(string)
+			reportId := createReport(t, token, "fire", "3.3792", "6.5244", "warehouse fire")
+			fileId := createFile(t, userId, "https://example.com/fire.jpg", "image/jpeg")
+
+			requestBody := []byte(fmt.Sprintf(`{"media_ids": ["%s"]}`, fileId))
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/media", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			media := tests.ParseResponse(t, response)["data"].(map[string]interface{})["media"].([]interface{})
+			if len(media) != 1 {
+				t.Fatalf("got media length: %d expected: %d", len(media), 1)
+			}
+			if got := media[0].(map[string]interface{})["file_id"]; got != fileId {
+				t.Errorf("got file_id: %v expected: %s", got, fileId)
+			}
+
+			email := "attacher" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "media", "user", email, userPassword)
+			otherToken, _ := logUserIn(t, email, userPassword)
+
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/media", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+		},
+	)
+	t.Run(`Given a report made by another user, when a user attaches their own 
+    file to it, the request is forbidden.
+    `,
+		func(t *testing.T) {
+			ownerToken, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, ownerToken, "fire", "3.3792", "6.5244", "warehouse fire")
+
+			email := "attacher" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			otherUserId := createUser(t, "media", "user", email, userPassword)
+			otherToken, _ := logUserIn(t, email, userPassword)
+			fileId := createFile(t, otherUserId, "https://example.com/other.jpg", "image/jpeg")
+
+			requestBody := []byte(fmt.Sprintf(`{"media_ids": ["%s"]}`, fileId))
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/media", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+		},
+	)
+	t.Run("test for attaching a file that does not exist", func(t *testing.T) {
+		token, _ := logUserIn(t, userEmail, userPassword)
+		reportId := createReport(t, token, "fire", "3.3792", "6.5244", "warehouse fire")
+
+		requestBody := []byte(fmt.Sprintf(`{"media_ids": ["%s"]}`, "0b0a2a0e-4f57-4b7f-8a3c-3c3f8d1f0c11"))
+		req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/media", bytes.NewBuffer(requestBody))
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestUpdateAndDeleteReport(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a report, when another user tries to edit or delete it, they 
+    get a 403, and when the owner deletes it, it is hidden from users and can 
+    no longer be voted or commented on, but is still visible to moderators.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			userId := getCurrentUser(t, token)["id"].(string)
+			reportId := createReport(t, token, "fire", "3.3792", "6.5244", "market fire")
+
+			email := "intruder" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "intruder", "user", email, userPassword)
+			otherToken, _ := logUserIn(t, email, userPassword)
+
+			requestBody := []byte(`{
+      "incident_type": "accident",
+      "location": {
+        "longitude": "3.3800",
+        "latitude": "6.5250"
+        },
+      "description": "it was an accident"
+      }`)
+			req, _ := http.NewRequest(http.MethodPut, route+"/"+reportId, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			req, _ = http.NewRequest(http.MethodPut, route+"/"+reportId, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["owner_id"] != userId {
+				t.Errorf("got owner_id: %v expected: %s", data["owner_id"], userId)
+			}
+			if data["incident_type"] != "accident" {
+				t.Errorf("got incident_type: %v expected: %s", data["incident_type"], "accident")
+			}
+
+			req, _ = http.NewRequest(http.MethodDelete, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			req, _ = http.NewRequest(http.MethodDelete, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/votes", bytes.NewBuffer([]byte(`{"vote": "confirm"}`)))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/comments", bytes.NewBuffer([]byte(`{"body": "is it still burning?"}`)))
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+
+			promoteUser(t, email, "moderator")
+			moderatorToken, _ := logUserIn(t, email, userPassword)
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+		},
+	)
+}
+
+func TestUserReports(t *testing.T) {
+	t.Run(`Given a user with reports, when they list their own reports, they get 
+    a total count and can filter by status, other users do not see the 
+    owner_id, and when they hide their history, other users are forbidden from 
+    listing it.
+    `,
+		func(t *testing.T) {
+			email := "historian" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "history", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			userName := getCurrentUser(t, token)["user_name"].(string)
+			createReport(t, token, "fire", "3.3792", "6.5244", "market fire")
+			resolvedReportId := createReport(t, token, "robbery", "3.3792", "6.5244", "bag snatched")
+
+			requestBody := []byte(`{"status": "resolved"}`)
+			req, _ := http.NewRequest(http.MethodPost, "/reports/"+resolvedReportId+"/status", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, "/users/me/reports?page=1&rows=1", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if total := data["total"].(float64); total != 2 {
+				t.Errorf("got total: %v expected: %d", total, 2)
+			}
+			if items := data["items"].([]interface{}); len(items) != 1 {
+				t.Errorf("got items length: %d expected: %d", len(items), 1)
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, "/users/me/reports?status=resolved", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if total := data["total"].(float64); total != 1 {
+				t.Errorf("got total: %v expected: %d", total, 1)
+			}
+
+			item := data["items"].([]interface{})[0].(map[string]interface{})
+			if item["owner_id"] == nil {
+				t.Errorf("expected owners to see the owner_id of their reports")
+			}
+
+			otherToken, _ := logUserIn(t, userEmail, userPassword)
+			req, _ = http.NewRequest(http.MethodGet, "/users/"+userName+"/reports", nil)
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			for _, item := range data["items"].([]interface{}) {
+				if owner := item.(map[string]interface{})["owner_id"]; owner != nil {
+					t.Errorf("expected no owner_id for other users, got %v", owner)
+				}
+			}
+
+			requestBody = []byte(`{"hide_reports": true}`)
+			req, _ = http.NewRequest(http.MethodPut, "/users/privacy", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, "/users/"+userName+"/reports", nil)
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+		},
+	)
+}
+
+func TestSearchReports(t *testing.T) {
+	route := "/reports/search"
+	t.Run(`Given reports mentioning a place, when a phrase is searched, only the 
+    matching reports are returned with highlighted snippets.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			place := "Bridge" + fmt.Sprint(tests.GenerateUniqueId())
+			createReport(t, token, "accident", "3.3792", "6.5244", "tanker overturned on Third Mainland "+place)
+			createReport(t, token, "accident", "3.3792", "6.5244", place+" closed, third lane mainland traffic")
+
+			query := url.QueryEscape(fmt.Sprintf(`"third mainland %s"`, place))
+			req, _ := http.NewRequest(http.MethodGet, route+"?q="+query, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			items := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(items) != 1 {
+				t.Fatalf("got items length: %d expected: %d", len(items), 1)
+			}
+			snippet := items[0].(map[string]interface{})["snippet"].(string)
+			if !strings.Contains(snippet, "<mark>") {
+				t.Errorf("expected snippet %q to highlight the matched terms", snippet)
+			}
+		},
+	)
+	t.Run(`Given a matching report has expired, when the phrase is searched, only 
+    the live report is returned.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			place := "Junction" + fmt.Sprint(tests.GenerateUniqueId())
+			liveReportId := createReport(t, token, "accident", "3.3792", "6.5244", "trailer stuck at "+place)
+			expiredReportId := createReport(t, token, "accident", "3.3792", "6.5244", "bus broke down at "+place)
+			_, err := postgresConnection.Exec("UPDATE reports SET status = 'expired' WHERE id = $1", expiredReportId)
+			if err != nil {
+				t.Fatalf("Unable to expire report: %v", err)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, route+"?q="+url.QueryEscape(place), nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			items := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(items) != 1 || items[0].(map[string]interface{})["id"] != liveReportId {
+				t.Errorf("expected only the live report, got %v", items)
+			}
+		},
+	)
+	t.Run("test for searching without a query", func(t *testing.T) {
+		token, _ := logUserIn(t, userEmail, userPassword)
+		req, _ := http.NewRequest(http.MethodGet, route+"?q=", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestStreamReports(t *testing.T) {
+	route := "/reports/stream"
+	server := httptest.NewServer(appRouter)
+	defer server.Close()
+
+	t.Run(`Given a user is connected to the report stream, when a matching report 
+    is created, it is pushed to them, and when they reconnect with the last 
+    event id, they receive the events they missed.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			description := "stream fire " + fmt.Sprint(tests.GenerateUniqueId())
+
+			stream := openReportStream(t, server.URL+route+"?incident_type=fire", token, "")
+			createReport(t, token, "accident", "3.3792", "6.5244", "not streamed")
+			createReport(t, token, "fire", "3.3792", "6.5244", description)
+			eventId, eventType, data := readReportEvent(t, stream)
+			stream.Close()
+
+			if eventType != "report.created" {
+				t.Errorf("got event: %s expected: %s", eventType, "report.created")
+			}
+			if !strings.Contains(data, description) {
+				t.Errorf("expected event data %q to contain %q", data, description)
+			}
+
+			missedDescription := "missed fire " + fmt.Sprint(tests.GenerateUniqueId())
+			createReport(t, token, "fire", "3.3792", "6.5244", missedDescription)
+			time.Sleep(200 * time.Millisecond)
+
+			stream = openReportStream(t, server.URL+route+"?incident_type=fire", token, eventId)
+			defer stream.Close()
+			_, _, data = readReportEvent(t, stream)
+			if !strings.Contains(data, missedDescription) {
+				t.Errorf("expected resumed event data %q to contain %q", data, missedDescription)
+			}
+		},
+	)
+	t.Run("test for an incomplete bounding box", func(t *testing.T) {
+		token, _ := logUserIn(t, userEmail, userPassword)
+		req, _ := http.NewRequest(http.MethodGet, route+"?min_lat=6.4", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func openReportStream(t *testing.T, streamUrl, token, lastEventId string) io.ReadCloser {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, streamUrl, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if lastEventId != "" {
+		req.Header.Set("Last-Event-ID", lastEventId)
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unable to open report stream: %v", err)
+	}
+	tests.AssertStatusCode(t, http.StatusOK, response.StatusCode)
+	return response.Body
+}
+
+// readReportEvent returns the first event on the stream, giving up after a
+// few seconds.
+func readReportEvent(t testing.TB, stream io.Reader) (string, string, string) {
+	t.Helper()
+	type event struct{ id, eventType, data string }
+	events := make(chan event, 1)
+	go func() {
+		var current event
+		scanner := bufio.NewScanner(stream)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				current.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.eventType = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				current.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && current.id != "":
+				events <- current
+				return
+			}
+		}
+	}()
+
+	select {
+	case received := <-events:
+		return received.id, received.eventType, received.data
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for a report event")
+		return "", "", ""
+	}
+}
+
+func TestRealtimeSession(t *testing.T) {
+	server := httptest.NewServer(appRouter)
+	defer server.Close()
+
+	t.Run(`Given a user opens a WebSocket session and subscribes to an area, when 
+    a report is created inside it, the event is delivered with the matching 
+    subscription, and pings are answered.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			wsUrl := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?access_token=" + token
+			conn, err := websocket.Dial(wsUrl, "", server.URL)
+			if err != nil {
+				t.Fatalf("Unable to open WebSocket session: %v", err)
+			}
+			defer conn.Close()
+
+			sendRealtimeMessage(t, conn, map[string]interface{}{
+				"type":            "subscribe",
+				"subscription_id": "lagos-fires",
+				"incident_types":  []string{"fire"},
+				"area": map[string]float64{
+					"min_lat": 6.4, "min_lng": 3.3, "max_lat": 6.6, "max_lng": 3.5,
+				},
+			})
+			if message := receiveRealtimeMessage(t, conn); message["type"] != "subscribed" {
+				t.Fatalf("got message: %v expected: %s", message["type"], "subscribed")
+			}
+
+			sendRealtimeMessage(t, conn, map[string]interface{}{
+				"type":     "ping",
+				"location": map[string]float64{"lat": 6.5244, "lng": 3.3792},
+			})
+			if message := receiveRealtimeMessage(t, conn); message["type"] != "pong" {
+				t.Fatalf("got message: %v expected: %s", message["type"], "pong")
+			}
+
+			description := "realtime fire " + fmt.Sprint(tests.GenerateUniqueId())
+			createReport(t, token, "fire", "3.3792", "6.5244", description)
+
+			message := receiveRealtimeMessage(t, conn)
+			if message["type"] != "event" {
+				t.Fatalf("got message: %v expected: %s", message["type"], "event")
+			}
+			report := message["report"].(map[string]interface{})
+			if report["description"] != description {
+				t.Errorf("got description: %v expected: %s", report["description"], description)
+			}
+			subscriptionIds := message["subscription_ids"].([]interface{})
+			if len(subscriptionIds) != 1 || subscriptionIds[0] != "lagos-fires" {
+				t.Errorf("got subscription_ids: %v expected: %v", subscriptionIds, []string{"lagos-fires"})
+			}
+
+			sendRealtimeMessage(t, conn, map[string]interface{}{"type": "dance"})
+			if message := receiveRealtimeMessage(t, conn); message["type"] != "error" {
+				t.Errorf("got message: %v expected: %s", message["type"], "error")
+			}
+		},
+	)
+	t.Run("test for opening a session without a token", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/ws", nil)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func sendRealtimeMessage(t testing.TB, conn *websocket.Conn, message map[string]interface{}) {
+	t.Helper()
+	if err := websocket.JSON.Send(conn, message); err != nil {
+		t.Fatalf("Unable to send realtime message: %v", err)
+	}
+}
+
+func receiveRealtimeMessage(t testing.TB, conn *websocket.Conn) map[string]interface{} {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var message map[string]interface{}
+	if err := websocket.JSON.Receive(conn, &message); err != nil {
+		t.Fatalf("Unable to receive realtime message: %v", err)
+	}
+	return message
+}
+
+func TestAlertSubscriptions(t *testing.T) {
+	route := "/users/me/alerts"
+	t.Run(`Given a user, when they register circle and polygon alerts, they can 
+    list, update and delete them, invalid areas are rejected and the number of 
+    subscriptions is capped.
+    `,
+		func(t *testing.T) {
+			email := "watcher" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "alert", "watcher", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+
+			requestBody := []byte(`{
+        "name": "home",
+        "shape": "circle",
+        "center": {"latitude": 6.5244, "longitude": 3.3792},
+        "radius_in_meters": 1000,
+        "incident_types": ["fire"],
+        "quiet_hours": {"start": "22:00", "end": "06:00"},
+        "time_zone": "Africa/Lagos"
+      }`)
+			req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			alertId := data["id"].(string)
+			quietHours := data["quiet_hours"].(map[string]interface{})
+			if quietHours["start"] != "22:00" || quietHours["end"] != "06:00" {
+				t.Errorf("got quiet_hours: %v expected: 22:00 to 06:00", quietHours)
+			}
+
+			requestBody = []byte(`{
+        "name": "school",
+        "shape": "polygon",
+        "polygon": [
+          {"latitude": 6.50, "longitude": 3.37},
+          {"latitude": 6.52, "longitude": 3.37},
+          {"latitude": 6.52, "longitude": 3.39}
+        ]
+      }`)
+			req, _ = http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			for _, body := range []string{
+				`{"name": "work", "shape": "circle", "center": {"latitude": 6.5, "longitude": 3.3}, "radius_in_meters": 10}`,
+				`{"name": "work", "shape": "polygon", "polygon": [{"latitude": 6.5, "longitude": 3.3}]}`,
+				`{"name": "work", "shape": "circle", "center": {"latitude": 6.5, "longitude": 3.3}, "radius_in_meters": 500, "quiet_hours": {"start": "25:00", "end": "06:00"}}`,
+				`{"name": "work", "shape": "circle", "center": {"latitude": 6.5, "longitude": 3.3}, "radius_in_meters": 500, "incident_types": ["not_a_type"]}`,
+			} {
+				req, _ = http.NewRequest(http.MethodPost, route, bytes.NewBufferString(body))
+				req.Header.Set("Authorization", "Bearer "+token)
+				response = tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+			}
+
+			requestBody = []byte(`{
+        "name": "home",
+        "shape": "circle",
+        "center": {"latitude": 6.5244, "longitude": 3.3792},
+        "radius_in_meters": 2000
+      }`)
+			req, _ = http.NewRequest(http.MethodPut, route+"/"+alertId, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["quiet_hours"] != nil {
+				t.Errorf("got quiet_hours: %v expected: nil", data["quiet_hours"])
+			}
+
+			otherToken, _ := logUserIn(t, userEmail, userPassword)
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+alertId, nil)
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if rows := data["rows"].(float64); rows != 2 {
+				t.Errorf("got rows: %v expected: %d", rows, 2)
+			}
+			limit := int(data["limit"].(float64))
+
+			requestBody = []byte(`{
+        "name": "market",
+        "shape": "circle",
+        "center": {"latitude": 6.5244, "longitude": 3.3792},
+        "radius_in_meters": 500
+      }`)
+			for i := 2; i < limit; i++ {
+				req, _ = http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+				req.Header.Set("Authorization", "Bearer "+token)
+				response = tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			}
+			req, _ = http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusConflict, response.Code)
+
+			req, _ = http.NewRequest(http.MethodDelete, route+"/"+alertId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+alertId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+		},
+	)
+}
+
+func TestNotifications(t *testing.T) {
+	t.Run(`Given a user, when they register a web push device twice, only one 
+    device is kept, and it can be removed again.
+    `,
+		func(t *testing.T) {
+			email := "pushed" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "push", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+
+			requestBody := []byte(fmt.Sprintf(`{
+        "platform": "web",
+        "token": "https://push.example.com/send/%d",
+        "keys": {"p256dh": "BNcRdreALRFXTkOOUHK1EtK2wtaz5Ry4YfYCA_0QTpQtUbVlUls0VJXg7A8u-Ts1XbjhazAkj7I99e8QcYP7DkM", "auth": "tBHItJI5svbpez7KI4CCXg"}
+      }`, tests.GenerateUniqueId()))
+			var deviceId string
+			for i := 0; i < 2; i++ {
+				req, _ := http.NewRequest(http.MethodPost, "/users/me/devices", bytes.NewBuffer(requestBody))
+				req.Header.Set("Authorization", "Bearer "+token)
+				response := tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusOK, response.Code)
+				deviceId = tests.ParseResponse(t, response)["data"].(map[string]interface{})["id"].(string)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "/users/me/devices", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if rows := data["rows"].(float64); rows != 1 {
+				t.Errorf("got rows: %v expected: %d", rows, 1)
+			}
+
+			requestBody = []byte(`{"platform": "pager", "token": "https://push.example.com/x", "keys": {"p256dh": "a", "auth": "b"}}`)
+			req, _ = http.NewRequest(http.MethodPost, "/users/me/devices", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+
+			otherToken, _ := logUserIn(t, userEmail, userPassword)
+			req, _ = http.NewRequest(http.MethodDelete, "/users/me/devices/"+deviceId, nil)
+			req.Header.Set("Authorization", "Bearer "+otherToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+
+			req, _ = http.NewRequest(http.MethodDelete, "/users/me/devices/"+deviceId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+		},
+	)
+	t.Run(`Given a user with an alert subscription, when a report is made inside 
+    it, a push notification is dispatched unless push is turned off.
+    `,
+		func(t *testing.T) {
+			email := "alerted" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "alerted", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			userId := getCurrentUser(t, token)["id"].(string)
+
+			req, _ := http.NewRequest(http.MethodGet, "/users/me/notification-preferences", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			channels := tests.ParseResponse(t, response)["data"].(map[string]interface{})["channels"].(map[string]interface{})
+			if channels["push"] != true || channels["email"] != true {
+				t.Errorf("got channels: %v expected every channel to be enabled", channels)
+			}
+
+			requestBody := []byte(`{"channels": {"sms": true}}`)
+			req, _ = http.NewRequest(http.MethodPut, "/users/me/notification-preferences", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+
+			requestBody = []byte(`{"channels": {"email": false}}`)
+			req, _ = http.NewRequest(http.MethodPut, "/users/me/notification-preferences", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			requestBody = []byte(`{
+        "name": "office",
+        "shape": "circle",
+        "center": {"latitude": 6.4281, "longitude": 3.4219},
+        "radius_in_meters": 1000
+      }`)
+			req, _ = http.NewRequest(http.MethodPost, "/users/me/alerts", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			reporterToken, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, reporterToken, "fire", "3.4225", "6.4285", "smoke from the office block")
+
+			deadline := time.Now().Add(5 * time.Second)
+			for !hasNotification(t, userId, reportId) {
+				if time.Now().After(deadline) {
+					t.Fatalf("no notification was dispatched for report %s", reportId)
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		},
+	)
+}
+
+func TestDuplicateReports(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a report was just made nearby, when another user submits a 
+    similar report asking for duplicate checks, they are offered the existing 
+    report and can corroborate it instead.
+    `,
+		func(t *testing.T) {
+			ownerEmail := "owner" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "owner", "user", ownerEmail, userPassword)
+			ownerToken, _ := logUserIn(t, ownerEmail, userPassword)
+			primaryId := createReport(t, ownerToken, "fire", "-58.3816", "-34.6037", "warehouse fire spreading towards market")
+
+			token, _ := logUserIn(t, userEmail, userPassword)
+			requestBody := []byte(`{
+        "incident_type": "fire",
+        "location": {"longitude": "-58.3817", "latitude": "-34.6038"},
+        "description": "big fire at the warehouse by the market",
+        "check_duplicates": true
+      }`)
+			req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusConflict, response.Code)
+
+			duplicates := tests.ParseResponse(t, response)["data"].([]interface{})
+			if len(duplicates) != 1 {
+				t.Fatalf("got duplicates: %d expected: %d", len(duplicates), 1)
+			}
+			tests.AssertResponseMessage(t, duplicates[0].(map[string]interface{})["id"].(string), primaryId)
+
+			requestBody = []byte(fmt.Sprintf(`{
+        "incident_type": "fire",
+        "location": {"longitude": "-58.3817", "latitude": "-34.6038"},
+        "description": "big fire at the warehouse by the market",
+        "corroborates": "%s"
+      }`, primaryId))
+			req, _ = http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["duplicate_of"].(string), primaryId)
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+primaryId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["corroboration_count"].(float64) != 1 {
+				t.Errorf("got corroboration_count: %v expected: %d", data["corroboration_count"], 1)
+			}
+		},
+	)
+	t.Run(`Given a report, when another user corroborates it anonymously, the 
+    corroboration is counted and linked without an actor.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			primaryId := createReport(t, token, "cult", "-58.4816", "-34.7037", "gathering behind the school")
+
+			email := "anonymous" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "anonymous", "user", email, userPassword)
+			anonymousToken, _ := logUserIn(t, email, userPassword)
+			requestBody := []byte(fmt.Sprintf(`{
+        "incident_type": "cult",
+        "location": {"longitude": "-58.4817", "latitude": "-34.7038"},
+        "description": "they are still behind the school",
+        "anonymous": true,
+        "corroborates": "%s"
+      }`, primaryId))
+			req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+anonymousToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			reportId := tests.ParseResponse(t, response)["data"].(map[string]interface{})["id"].(string)
+
+			var actorIsNull bool
+			err := postgresConnection.Get(&actorIsNull,
+				"SELECT actor_id IS NULL FROM report_links WHERE report_id = $1 AND action = 'corroborate'", reportId)
+			if err != nil {
+				t.Fatalf("Unable to get the report link: %v", err)
+			}
+			if !actorIsNull {
+				t.Errorf("expected the anonymous corroboration to have no actor")
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+primaryId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["corroboration_count"].(float64) != 1 {
+				t.Errorf("got corroboration_count: %v expected: %d", data["corroboration_count"], 1)
+			}
+		},
+	)
+	t.Run(`Given two separate reports of the same incident, when a moderator 
+    merges one into the other and later splits it off, both changes are kept 
+    in the report links.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			primaryId := createReport(t, token, "accident", "-43.1729", "-22.9068", "bus overturned on the road")
+			reportId := createReport(t, token, "accident", "-43.1730", "-22.9069", "crash blocking the road")
+
+			req, _ := http.NewRequest(http.MethodPost, route+"/"+reportId+"/merge",
+				bytes.NewBuffer([]byte(fmt.Sprintf(`{"into": "%s"}`, primaryId))))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			email := "moderator" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "moderator", "user", email, adminPassword)
+			promoteUser(t, email, "moderator")
+			moderatorToken, _ := logUserIn(t, email, adminPassword)
+
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/merge",
+				bytes.NewBuffer([]byte(fmt.Sprintf(`{"into": "%s"}`, primaryId))))
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["corroboration_count"].(float64) != 1 {
+				t.Errorf("got corroboration_count: %v expected: %d", data["corroboration_count"], 1)
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+primaryId+"/corroborations", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			corroborations := tests.ParseResponse(t, response)["data"].([]interface{})
+			if len(corroborations) != 1 {
+				t.Fatalf("got corroborations: %d expected: %d", len(corroborations), 1)
+			}
+
+			req, _ = http.NewRequest(http.MethodPost, route+"/"+reportId+"/split", nil)
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["duplicate_of"] != nil {
+				t.Errorf("expected the split report to be a primary report, got duplicate_of: %v", data["duplicate_of"])
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId+"/links", nil)
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			links := tests.ParseResponse(t, response)["data"].([]interface{})
+			if len(links) != 2 {
+				t.Fatalf("got links: %d expected: %d", len(links), 2)
+			}
+			tests.AssertResponseMessage(t, links[0].(map[string]interface{})["action"].(string), "merge")
+			tests.AssertResponseMessage(t, links[1].(map[string]interface{})["action"].(string), "split")
+			if links[0].(map[string]interface{})["actor_id"] == nil {
+				t.Errorf("expected the moderator to see who merged the report")
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId+"/links", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+		},
+	)
+}
+
+func TestAnonymousReports(t *testing.T) {
+	route := "/reports"
+	createAnonymousReport := func(token, incidentType string) *httptest.ResponseRecorder {
+		requestBody := []byte(fmt.Sprintf(`{
+        "incident_type": "%s",
+        "location": {"longitude": "3.3792", "latitude": "6.5244"},
+        "description": "seen gathering behind the school",
+        "anonymous": true
+      }`, incidentType))
+		req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+		req.Header.Set("Authorization", "Bearer "+token)
+		return tests.ExecuteRequest(req, appRouter)
+	}
+
+	t.Run(`Given an incident type that does not allow anonymity, when a user 
+    reports it anonymously, they receive a bad request response.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			response := createAnonymousReport(token, "fire")
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+	t.Run(`Given a user reports cult activity anonymously, when the report is 
+    retrieved, the owner is not shown, only admins can look the owner up and 
+    the user is rate limited after a few anonymous reports.
+    `,
+		func(t *testing.T) {
+			email := "anonymous" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "anonymous", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+
+			response := createAnonymousReport(token, "cult")
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			reportId := data["id"].(string)
+			if data["owner_id"] != nil || data["is_anonymous"] != true {
+				t.Errorf("expected an anonymous report, got owner_id: %v is_anonymous: %v", data["owner_id"], data["is_anonymous"])
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			if owner := tests.ParseResponse(t, response)["data"].(map[string]interface{})["owner_id"]; owner != nil {
+				t.Errorf("expected no owner_id, got %v", owner)
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, "/admin/reports/"+reportId+"/owner", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			lookupEmail := "admin" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "admin", "user", lookupEmail, adminPassword)
+			promoteUser(t, lookupEmail, "admin")
+			adminToken, _ := logUserIn(t, lookupEmail, adminPassword)
+			req, _ = http.NewRequest(http.MethodGet, "/admin/reports/"+reportId+"/owner", nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data = tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["owner_id"].(string), userId)
+
+			for i := 1; i < reports.MaxAnonymousReportsPerHour; i++ {
+				response = createAnonymousReport(token, "cult")
+				tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			}
+			response = createAnonymousReport(token, "cult")
+			tests.AssertStatusCode(t, http.StatusTooManyRequests, response.Code)
+		},
+	)
+}
+
+func TestReportExpiry(t *testing.T) {
+	route := "/reports"
+	t.Run(`Given a report older than the time to live of its incident type, when 
+    the expiry job runs, the report is expired, it leaves the default feed and 
+    can still be listed by asking for expired reports.
+    `,
+		func(t *testing.T) {
+			email := "reporter" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "reporter", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			staleReportId := createReport(t, token, "accident", "3.3792", "6.5244", "truck on its side")
+			liveReportId := createReport(t, token, "accident", "3.3792", "6.5244", "another truck on its side")
+
+			_, err := postgresConnection.Exec(
+				"UPDATE reports SET created_at = NOW() - INTERVAL '13 hours' WHERE id = $1", staleReportId)
+			if err != nil {
+				t.Fatalf("Unable to age report: %v", err)
+			}
+			if err := reportExpiryJob.Run(context.Background()); err != nil {
+				t.Fatalf("expiry job failed: %v", err)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, route+"/"+staleReportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["status"].(string), "expired")
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/latest?owner_id="+userId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			items := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(items) != 1 || items[0].(map[string]interface{})["id"] != liveReportId {
+				t.Errorf("expected only the live report in the feed, got %v", items)
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/latest?status=expired&owner_id="+userId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			items = tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(items) != 1 || items[0].(map[string]interface{})["id"] != staleReportId {
+				t.Errorf("expected the expired report, got %v", items)
+			}
+		},
+	)
+	t.Run(`Given a report queued offline for longer than the time to live of its 
+    incident type, when it is synced, it is rejected.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			requestBody := []byte(fmt.Sprintf(`{"reports": [{
+      "client_id": "%s",
+      "occurred_at": "%s",
+      "incident_type": "accident",
+      "location": {
+        "longitude": "3.3792",
+        "latitude": "6.5244"
+        },
+      "description": "queued while offline"
+      }]}`, uuid.NewString(), time.Now().Add(-13*time.Hour).UTC().Format(time.RFC3339)))
+			req, _ := http.NewRequest(http.MethodPost, route+"/batch", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			result := tests.ParseResponse(t, response)["data"].([]interface{})[0].(map[string]interface{})
+			tests.AssertResponseMessage(t, result["status"].(string), "failed")
+			if result["code"].(float64) != http.StatusBadRequest {
+				t.Errorf("got code: %v expected: %d", result["code"], http.StatusBadRequest)
+			}
+		},
+	)
+	t.Run(`Given a report queued offline, when the time to live of its incident 
+    type has passed since it occurred, the expiry job expires it even though it 
+    was made more recently.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			requestBody := []byte(fmt.Sprintf(`{"reports": [{
+      "client_id": "%s",
+      "occurred_at": "%s",
+      "incident_type": "accident",
+      "location": {
+        "longitude": "3.3792",
+        "latitude": "6.5244"
+        },
+      "description": "queued while offline"
+      }]}`, uuid.NewString(), time.Now().Add(-11*time.Hour).UTC().Format(time.RFC3339)))
+			req, _ := http.NewRequest(http.MethodPost, route+"/batch", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			result := tests.ParseResponse(t, response)["data"].([]interface{})[0].(map[string]interface{})
+			reportId := result["report"].(map[string]interface{})["id"].(string)
+
+			// two hours pass, the report was made less than the time to live ago
+			_, err := postgresConnection.Exec(`
+        UPDATE reports SET occurred_at = occurred_at - INTERVAL '2 hours', created_at = created_at - INTERVAL '2 hours'
+        WHERE id = $1`, reportId)
+			if err != nil {
+				t.Fatalf("Unable to age report: %v", err)
+			}
+			if err := reportExpiryJob.Run(context.Background()); err != nil {
+				t.Fatalf("expiry job failed: %v", err)
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			tests.AssertResponseMessage(t, data["status"].(string), "expired")
+		},
+	)
+}
+
+func TestReportsGeoJSON(t *testing.T) {
+	t.Run(`Given a user's reports, when they are listed as GeoJSON through the 
+    route or the Accept header, a FeatureCollection of points is returned.
+    `,
+		func(t *testing.T) {
+			email := "mapper" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "mapper", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			reportId := createReport(t, token, "fire", "3.3792", "6.5244", "bus on fire")
+
+			for _, route := range []string{"/reports.geojson", "/reports/latest"} {
+				req, _ := http.NewRequest(http.MethodGet, route+"?owner_id="+userId, nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+				req.Header.Set("Accept", "application/geo+json")
+				response := tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusOK, response.Code)
+				tests.AssertResponseMessage(t, response.Header().Get("Content-Type"), "application/geo+json")
+
+				collection := tests.ParseResponse(t, response)
+				tests.AssertResponseMessage(t, collection["type"].(string), "FeatureCollection")
+				features := collection["features"].([]interface{})
+				if len(features) != 1 {
+					t.Fatalf("expected 1 feature, got %d", len(features))
+				}
+				feature := features[0].(map[string]interface{})
+				tests.AssertResponseMessage(t, feature["id"].(string), reportId)
+				geometry := feature["geometry"].(map[string]interface{})
+				tests.AssertResponseMessage(t, geometry["type"].(string), "Point")
+				coordinates := geometry["coordinates"].([]interface{})
+				if coordinates[0] != 3.3792 || coordinates[1] != 6.5244 {
+					t.Errorf("expected coordinates [3.3792 6.5244], got %v", coordinates)
+				}
+				properties := feature["properties"].(map[string]interface{})
+				tests.AssertResponseMessage(t, properties["description"].(string), "bus on fire")
+			}
+		},
+	)
+	t.Run(`Given more reports than the limit, when they are listed as GeoJSON, 
+    the collection is cut at the limit and ends with a cursor to the rest.
+    `,
+		func(t *testing.T) {
+			email := "mapper" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "mapper", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			createReport(t, token, "fire", "3.3792", "6.5244", "bus on fire")
+			createReport(t, token, "fire", "3.3792", "6.5244", "shop on fire")
+
+			req, _ := http.NewRequest(http.MethodGet, "/reports.geojson?limit=1&owner_id="+userId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			collection := tests.ParseResponse(t, response)
+			if features := collection["features"].([]interface{}); len(features) != 1 {
+				t.Fatalf("expected 1 feature, got %d", len(features))
+			}
+			nextCursor, ok := collection["next_cursor"].(string)
+			if !ok {
+				t.Fatalf("expected a next_cursor")
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, "/reports.geojson?limit=1&owner_id="+userId+"&cursor="+nextCursor, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			collection = tests.ParseResponse(t, response)
+			if features := collection["features"].([]interface{}); len(features) != 1 {
+				t.Fatalf("expected 1 feature, got %d", len(features))
+			}
+			if _, ok := collection["next_cursor"]; ok {
+				t.Errorf("expected no next_cursor on the last page")
+			}
+
+			req, _ = http.NewRequest(http.MethodGet, "/reports.geojson?limit=0", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+	t.Run(`Given an invalid feed filter, when reports are listed as GeoJSON, 
+    a bad request response is returned.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			req, _ := http.NewRequest(http.MethodGet, "/reports.geojson?status=unknown", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+}
+
+func TestReportStats(t *testing.T) {
+	route := "/reports/stats"
+	t.Run(`Given reports made in an area, when a user asks for the stats of that 
+    area, they receive the counts grouped by incident type, time and cell.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			createReport(t, token, "fire", "100.0005", "70.0005", "warehouse on fire")
+			createReport(t, token, "fire", "100.0015", "70.0015", "smoke from the market")
+			createReport(t, token, "robbery", "100.0025", "70.0025", "shop robbed at night")
+
+			now := time.Now().UTC()
+			query := url.Values{
+				"min_lat":   {"70"},
+				"min_lng":   {"100"},
+				"max_lat":   {"70.01"},
+				"max_lng":   {"100.01"},
+				"from":      {now.Add(-time.Hour).Format(time.RFC3339)},
+				"to":        {now.Add(time.Hour).Format(time.RFC3339)},
+				"time_zone": {"Africa/Lagos"},
+			}
+			req, _ := http.NewRequest(http.MethodGet, route+"?"+query.Encode(), nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			data := tests.ParseResponse(t, response)["data"].(map[string]interface{})
+			if data["total"] != float64(3) {
+				t.Errorf("expected 3 reports, got %v", data["total"])
+			}
+			byIncidentType := data["by_incident_type"].([]interface{})
+			if len(byIncidentType) != 2 || byIncidentType[0].(map[string]interface{})["incident_type"] != "fire" {
+				t.Errorf("expected fire to be the most reported of 2 incident types, got %v", byIncidentType)
+			}
+			if hours := data["by_hour_of_day"].([]interface{}); len(hours) != 24 {
+				t.Errorf("expected 24 hours of day, got %d", len(hours))
+			}
+			if cells := data["by_cell"].([]interface{}); len(cells) != 3 {
+				t.Errorf("expected 3 cells, got %v", cells)
+			}
+		},
+	)
+	t.Run(`Given an invalid time zone or time range, when a user asks for stats, 
+    they receive a bad request response.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			for _, query := range []string{
+				"time_zone=Mars/Olympus",
+				"from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+				"from=2022-01-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			} {
+				req, _ := http.NewRequest(http.MethodGet, route+"?"+query, nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+				response := tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+			}
+		},
+	)
+}
+
+func TestReportAdminAreas(t *testing.T) {
+	route := "/reports"
+	getReport := func(t *testing.T, token, reportId string) map[string]interface{} {
+		req, _ := http.NewRequest(http.MethodGet, route+"/"+reportId, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusOK, response.Code)
+		return tests.ParseResponse(t, response)["data"].(map[string]interface{})
+	}
+
+	t.Run(`Given a report made in Ikeja, when it is created, it is tagged with 
+    its state and LGA and can be listed by either of them.
+    `,
+		func(t *testing.T) {
+			email := "ikeja" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "ikeja", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			reportId := createReport(t, token, "robbery", "3.35", "6.60", "phone snatched at the roundabout")
+
+			data := getReport(t, token, reportId)
+			if data["state"] != "NG-LA" || data["lga"] != "NG-LA-IKEJA" {
+				t.Errorf("expected NG-LA and NG-LA-IKEJA, got state: %v lga: %v", data["state"], data["lga"])
+			}
+
+			for filter, expected := range map[string]int{
+				"lga=ng-la-ikeja": 1,
+				"state=NG-LA":     1,
+				"state=NG-FC":     0,
+			} {
+				req, _ := http.NewRequest(http.MethodGet, route+"/latest?owner_id="+userId+"&"+filter, nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+				response := tests.ExecuteRequest(req, appRouter)
+				tests.AssertStatusCode(t, http.StatusOK, response.Code)
+				items := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+				if len(items) != expected {
+					t.Errorf("expected %d reports for %s, got %d", expected, filter, len(items))
+				}
+			}
+		},
+	)
+	t.Run(`Given a report that was never tagged, when the backfill runs, it is 
+    tagged with its state and LGA.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "robbery", "7.45", "9.0", "car broken into")
+			_, err := postgresConnection.Exec(
+				"UPDATE reports SET state_code = NULL, lga_code = NULL WHERE id = $1", reportId)
+			if err != nil {
+				t.Fatalf("Unable to untag report: %v", err)
+			}
+
+			reportsRepo, err := postgres.NewPostgresReportRepo(context.Background(), postgresConnection)
+			if err != nil {
+				t.Fatal(err)
+			}
+			geocoder, err := geocode.NewGeocoder(configurations.BoundariesFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := geocode.BackfillAdminAreas(context.Background(), reportsRepo, geocoder, false); err != nil {
+				t.Fatalf("backfill failed: %v", err)
+			}
+
+			data := getReport(t, token, reportId)
+			if data["state"] != "NG-FC" || data["lga"] != "NG-FC-AMAC" {
+				t.Errorf("expected NG-FC and NG-FC-AMAC, got state: %v lga: %v", data["state"], data["lga"])
+			}
+		},
+	)
+	t.Run(`Given a report tagged with the wrong areas, when the backfill runs 
+    with retag, it is tagged again with its state and LGA.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "robbery", "7.45", "9.0", "car broken into")
+			_, err := postgresConnection.Exec(
+				"UPDATE reports SET state_code = 'NG-LA', lga_code = 'NG-LA-IKEJA' WHERE id = $1", reportId)
+			if err != nil {
+				t.Fatalf("Unable to mistag report: %v", err)
+			}
+
+			reportsRepo, err := postgres.NewPostgresReportRepo(context.Background(), postgresConnection)
+			if err != nil {
+				t.Fatal(err)
+			}
+			geocoder, err := geocode.NewGeocoder(configurations.BoundariesFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := geocode.BackfillAdminAreas(context.Background(), reportsRepo, geocoder, false); err != nil {
+				t.Fatalf("backfill failed: %v", err)
+			}
+			if data := getReport(t, token, reportId); data["state"] != "NG-LA" {
+				t.Errorf("expected the backfill to leave tagged reports alone, got state: %v", data["state"])
+			}
+
+			if _, err := geocode.BackfillAdminAreas(context.Background(), reportsRepo, geocoder, true); err != nil {
+				t.Fatalf("backfill failed: %v", err)
+			}
+			data := getReport(t, token, reportId)
+			if data["state"] != "NG-FC" || data["lga"] != "NG-FC-AMAC" {
+				t.Errorf("expected NG-FC and NG-FC-AMAC, got state: %v lga: %v", data["state"], data["lga"])
+			}
+		},
+	)
+}
+
+func TestModeration(t *testing.T) {
+	moderate := func(t *testing.T, token, method, route, body string) *httptest.ResponseRecorder {
+		var req *http.Request
+		if body == "" {
+			req, _ = http.NewRequest(method, route, nil)
+		} else {
+			req, _ = http.NewRequest(method, route, bytes.NewBufferString(body))
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return tests.ExecuteRequest(req, appRouter)
+	}
+
+	t.Run(`Given a flagged report, when a moderator hides it, it leaves the 
+    public listings until it is restored, and a suspended reporter cannot make
+    reports.
+    `,
+		func(t *testing.T) {
+			reporterEmail := "reporter" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			reporterId := createUser(t, "reporter", "user", reporterEmail, userPassword)
+			reporterToken, _ := logUserIn(t, reporterEmail, userPassword)
+			reportId := createReport(t, reporterToken, "robbery", "3.35", "6.60", "fake robbery report")
+
+			response := moderate(t, reporterToken, http.MethodPost, "/reports/"+reportId+"/flags", `{"reason": "fake"}`)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+
+			userToken, _ := logUserIn(t, userEmail, userPassword)
+			response = moderate(t, userToken, http.MethodPost, "/reports/"+reportId+"/flags", `{"reason": "made up"}`)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+			response = moderate(t, userToken, http.MethodPost, "/reports/"+reportId+"/flags", `{"reason": "fake", "note": "never happened"}`)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			response = moderate(t, userToken, http.MethodPost, "/reports/"+reportId+"/flags", `{"reason": "spam"}`)
+			tests.AssertStatusCode(t, http.StatusConflict, response.Code)
+
+			response = moderate(t, userToken, http.MethodGet, "/moderation/queue", "")
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			moderatorEmail := "moderator" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "moderator", "user", moderatorEmail, adminPassword)
+			promoteUser(t, moderatorEmail, "moderator")
+			moderatorToken, _ := logUserIn(t, moderatorEmail, adminPassword)
+
+			response = moderate(t, moderatorToken, http.MethodGet, "/moderation/queue?rows=100", "")
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			var queued map[string]interface{}
+			for _, item := range tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{}) {
+				entry := item.(map[string]interface{})
+				if entry["report"].(map[string]interface{})["id"] == reportId {
+					queued = entry
+				}
+			}
+			if queued == nil {
+				t.Fatalf("expected report %s in the moderation queue", reportId)
+			}
+			if queued["pending_flags"] != float64(1) || queued["reasons"].(map[string]interface{})["fake"] != float64(1) {
+				t.Errorf("expected one fake flag, got %v", queued)
+			}
+
+			response = moderate(t, moderatorToken, http.MethodPost, "/moderation/reports/"+reportId+"/hide", `{"reason": "fake report"}`)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			response = moderate(t, moderatorToken, http.MethodPost, "/moderation/reports/"+reportId+"/hide", `{"reason": "fake report"}`)
+			tests.AssertStatusCode(t, http.StatusConflict, response.Code)
+
+			response = moderate(t, userToken, http.MethodGet, "/reports/"+reportId, "")
+			tests.AssertStatusCode(t, http.StatusNotFound, response.Code)
+			response = moderate(t, userToken, http.MethodGet, "/reports/latest?owner_id="+reporterId, "")
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			items := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(items) != 0 {
+				t.Errorf("expected hidden report to leave the feed, got %d reports", len(items))
+			}
+
+			response = moderate(t, moderatorToken, http.MethodPost, "/moderation/reports/"+reportId+"/restore", `{"reason": "confirmed by police"}`)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			response = moderate(t, userToken, http.MethodGet, "/reports/"+reportId, "")
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			response = moderate(t, moderatorToken, http.MethodPost, "/moderation/reports/"+reportId+"/suspend", `{"reason": "repeated fake reports", "days": 0}`)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+			response = moderate(t, moderatorToken, http.MethodPost, "/moderation/reports/"+reportId+"/suspend", `{"reason": "repeated fake reports", "days": 7}`)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			requestBody := `{
+      "incident_type": "robbery",
+      "location": {
+        "longitude": "3.35",
+        "latitude": "6.60"
+        },
+      "description": "another report"
+      }`
+			response = moderate(t, reporterToken, http.MethodPost, "/reports", requestBody)
+			tests.AssertStatusCode(t, http.StatusForbidden, response.Code)
+
+			response = moderate(t, moderatorToken, http.MethodGet, "/moderation/reports/"+reportId+"/actions", "")
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			actions := tests.ParseResponse(t, response)["data"].([]interface{})
+			if len(actions) != 3 {
+				t.Fatalf("expected 3 moderation actions, got %d", len(actions))
+			}
+			for i, expected := range []string{"hide", "restore", "suspend"} {
+				action := actions[i].(map[string]interface{})
+				if action["action"] != expected || action["moderator_id"] == "" {
+					t.Errorf("expected a %s action by the moderator, got %v", expected, action)
+				}
+			}
+		},
+	)
+}
+
+func TestReportContentFilter(t *testing.T) {
+	t.Run(`Given a description with a phone number and an email, when the 
+    report is created, both are masked and recorded for moderators.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "robbery", "3.35", "6.60",
+				"call 0803 123 4567 or write to victim@example.com")
+
+			req, _ := http.NewRequest(http.MethodGet, "/reports/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			description := tests.ParseResponse(t, response)["data"].(map[string]interface{})["description"]
+			expected := "call [phone number removed] or write to [email removed]"
+			if description != expected {
+				t.Errorf("expected description %q, got %q", expected, description)
+			}
+
+			moderatorEmail := "moderator" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "moderator", "user", moderatorEmail, adminPassword)
+			promoteUser(t, moderatorEmail, "moderator")
+			moderatorToken, _ := logUserIn(t, moderatorEmail, adminPassword)
+
+			req, _ = http.NewRequest(http.MethodGet, "/moderation/reports/"+reportId+"/content-findings", nil)
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			matches := map[string]interface{}{}
+			for _, item := range tests.ParseResponse(t, response)["data"].([]interface{}) {
+				finding := item.(map[string]interface{})
+				matches[finding["rule"].(string)] = finding["match"]
+			}
+			if matches["phone_number"] != "0803 123 4567" || matches["email"] != "victim@example.com" {
+				t.Errorf("expected the phone number and email to be recorded, got %v", matches)
+			}
+		},
+	)
+	t.Run(`Given a description with a word from a rejecting word list, when the 
+    report is created, it is rejected.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			requestBody := []byte(`{
+      "incident_type": "robbery",
+      "location": {
+        "longitude": "3.35",
+        "latitude": "6.60"
+        },
+      "description": "na WAHALA word be this"
+      }`)
+			req, _ := http.NewRequest(http.MethodPost, "/reports", bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusBadRequest, response.Code)
+		},
+	)
+	t.Run(`Given a description with a flagged phrase written without tone 
+    marks, when the report is created, it goes to the moderation queue.
+    `,
+		func(t *testing.T) {
+			token, _ := logUserIn(t, userEmail, userPassword)
+			reportId := createReport(t, token, "robbery", "3.35", "6.60", "won so oro buruku si wa")
+
+			moderatorEmail := "moderator" + fmt.Sprint(tests.GenerateUniqueId()) + "@app.com"
+			createUser(t, "moderator", "user", moderatorEmail, adminPassword)
+			promoteUser(t, moderatorEmail, "moderator")
+			moderatorToken, _ := logUserIn(t, moderatorEmail, adminPassword)
+
+			req, _ := http.NewRequest(http.MethodGet, "/moderation/queue?rows=100", nil)
+			req.Header.Set("Authorization", "Bearer "+moderatorToken)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			queued := false
+			for _, item := range tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{}) {
+				if item.(map[string]interface{})["report"].(map[string]interface{})["id"] == reportId {
+					queued = true
+				}
+			}
+			if !queued {
+				t.Errorf("expected report %s in the moderation queue", reportId)
+			}
+		},
+	)
+}
+
+func TestIdempotentReportSubmission(t *testing.T) {
+	route := "/reports"
+	submit := func(t *testing.T, token, key, description string) *httptest.ResponseRecorder {
+		requestBody := []byte(fmt.Sprintf(`{
+      "incident_type": "robbery",
+      "location": {
+        "longitude": "3.35",
+        "latitude": "6.60"
+        },
+      "description": "%s"
+      }`, description))
+		req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Idempotency-Key", key)
+		return tests.ExecuteRequest(req, appRouter)
+	}
+
+	t.Run(`Given a report submitted with an Idempotency-Key, when it is 
+    retried, the original response is replayed and no duplicate is created, 
+    and reusing the key for another report fails.
+    `,
+		func(t *testing.T) {
+			email := "retry" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "retry", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			key := fmt.Sprint(tests.GenerateUniqueId())
+
+			first := submit(t, token, key, "bag snatched at the bus stop")
+			tests.AssertStatusCode(t, http.StatusOK, first.Code)
+			reportId := tests.ParseResponse(t, first)["data"].(map[string]interface{})["id"]
+
+			retry := submit(t, token, key, "bag snatched at the bus stop")
+			tests.AssertStatusCode(t, http.StatusOK, retry.Code)
+			if retry.Header().Get("Idempotent-Replayed") != "true" {
+				t.Errorf("expected the retry to be replayed")
+			}
+			if id := tests.ParseResponse(t, retry)["data"].(map[string]interface{})["id"]; id != reportId {
+				t.Errorf("expected the retry to return report %v, got %v", reportId, id)
+			}
+
+			response := submit(t, token, key, "a different report")
+			tests.AssertStatusCode(t, http.StatusUnprocessableEntity, response.Code)
+
+			req, _ := http.NewRequest(http.MethodGet, route+"/latest?owner_id="+userId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response = tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			items := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(items) != 1 {
+				t.Errorf("expected 1 report, got %d", len(items))
+			}
+		},
+	)
+}
+
+func TestReportBatch(t *testing.T) {
+	route := "/reports/batch"
+	sync := func(t *testing.T, token string, items ...string) []interface{} {
+		requestBody := []byte(`{"reports": [` + strings.Join(items, ",") + `]}`)
+		req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := tests.ExecuteRequest(req, appRouter)
+		tests.AssertStatusCode(t, http.StatusOK, response.Code)
+		return tests.ParseResponse(t, response)["data"].([]interface{})
+	}
+	batchItem := func(clientId, incidentType string, occurredAt time.Time) string {
+		return fmt.Sprintf(`{
+      "client_id": "%s",
+      "occurred_at": "%s",
+      "incident_type": "%s",
+      "location": {
+        "longitude": "3.35",
+        "latitude": "6.60"
+        },
+      "description": "queued while offline"
+      }`, clientId, occurredAt.UTC().Format(time.RFC3339), incidentType)
+	}
+
+	t.Run(`Given a batch of queued reports, when it is synced twice, each 
+    report is made once and invalid reports fail on their own.
+    `,
+		func(t *testing.T) {
+			email := "offline" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			userId := createUser(t, "offline", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			queuedId, invalidId, futureId := uuid.NewString(), uuid.NewString(), uuid.NewString()
+			items := []string{
+				batchItem(queuedId, "robbery", time.Now().Add(-3*time.Hour)),
+				batchItem(invalidId, "", time.Now().Add(-2*time.Hour)),
+				batchItem(futureId, "robbery", time.Now().Add(2*time.Hour)),
+			}
+
+			results := sync(t, token, items...)
+			if len(results) != 3 {
+				t.Fatalf("expected 3 results, got %d", len(results))
+			}
+			first := results[0].(map[string]interface{})
+			if first["client_id"] != queuedId || first["status"] != "created" {
+				t.Fatalf("expected the queued report to be created, got %v", first)
+			}
+			reportId := first["report"].(map[string]interface{})["id"]
+			if first["report"].(map[string]interface{})["occurred_at"] == nil {
+				t.Errorf("expected the report to keep occurred_at")
+			}
+			for _, result := range results[1:] {
+				failed := result.(map[string]interface{})
+				if failed["status"] != "failed" || failed["code"] != float64(http.StatusBadRequest) {
+					t.Errorf("expected the report to fail with 400, got %v", failed)
+				}
+			}
+
+			results = sync(t, token, items[0])
+			again := results[0].(map[string]interface{})
+			if again["status"] != "duplicate" || again["report"].(map[string]interface{})["id"] != reportId {
+				t.Errorf("expected report %v to be returned as a duplicate, got %v", reportId, again)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "/reports/latest?owner_id="+userId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+			feed := tests.ParseResponse(t, response)["data"].(map[string]interface{})["items"].([]interface{})
+			if len(feed) != 1 {
+				t.Errorf("expected 1 report, got %d", len(feed))
+			}
+
+			otherToken, _ := logUserIn(t, userEmail, userPassword)
+			results = sync(t, otherToken, items[0])
+			other := results[0].(map[string]interface{})
+			if other["status"] != "created" || other["report"].(map[string]interface{})["id"] == reportId {
+				t.Errorf("expected another user's report with the same client_id to be created, got %v", other)
+			}
+		},
+	)
+	t.Run(`Given a synced report that was deleted since, when it is synced again, 
+    it fails as gone instead of being returned as a duplicate.
+    `,
+		func(t *testing.T) {
+			email := "offline" + fmt.Sprint(tests.GenerateUniqueId()) + "@gmail.com"
+			createUser(t, "offline", "user", email, userPassword)
+			token, _ := logUserIn(t, email, userPassword)
+			item := batchItem(uuid.NewString(), "robbery", time.Now().Add(-time.Hour))
+
+			created := sync(t, token, item)[0].(map[string]interface{})
+			reportId := created["report"].(map[string]interface{})["id"].(string)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/reports/"+reportId, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			response := tests.ExecuteRequest(req, appRouter)
+			tests.AssertStatusCode(t, http.StatusOK, response.Code)
+
+			removed := sync(t, token, item)[0].(map[string]interface{})
+			if removed["status"] != "failed" || removed["code"] != float64(http.StatusGone) || removed["report"] != nil {
+				t.Errorf("expected the deleted report to fail with 410, got %v", removed)
+			}
+		},
+	)
+}
+
+func hasNotification(t testing.TB, userId, reportId string) bool {
+	content, err := os.ReadFile(notificationLogFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.Contains(line, `"user_id":"`+userId+`"`) && strings.Contains(line, `"report_id":"`+reportId+`"`) {
+			return true
+		}
+	}
+	return false
+}
+
+func createReport(t testing.TB, token, incidentType, longitude, latitude, description string) string {
+	t.Helper()
+	route := "/reports"
+	requestBody := []byte(fmt.Sprintf(`{
+      "incident_type": "%s",
+      "location": {
+        "longitude": "%s",
+        "latitude": "%s"
+        },
+      "description": "%s"
+      }`, incidentType, longitude, latitude, description))
+	req, _ := http.NewRequest(http.MethodPost, route, bytes.NewBuffer(requestBody))
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	response := tests.ExecuteRequest(req, appRouter)
+	responseBody := tests.ParseResponse(t, response)
+	data := responseBody["data"].(map[string]interface{})
+	reportId := data["id"].(string)
+
+	return reportId
+}
+
+// createFile stores a file record directly so tests do not depend on the
+// remote file store.
+func createFile(t testing.TB, ownerId, url, mimeType string) string {
+	t.Helper()
+	fileId := uuid.NewString()
+	_, err := postgresConnection.Exec(`
+    INSERT INTO files (id, owner_id, url, mime_type, created_at)
+    VALUES ($1, $2, $3, $4, NOW())`,
+		fileId, ownerId, url, mimeType)
+	if err != nil {
+		t.Fatalf("Unable to create file: %v", err)
+	}
+	return fileId
+}
+
+func promoteUser(t testing.TB, email, role string) {
+	t.Helper()
+	_, err := postgresConnection.Exec("UPDATE users SET role = $1 WHERE email = $2", role, email)
+	if err != nil {
+		t.Fatalf("Unable to promote user %q: %v", email, err)
+	}
 }
 
 func createUser(t testing.TB, firstName, lastName, email, password string) string {